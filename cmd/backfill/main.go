@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/backfill"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/blockreward"
+	"github.com/powerslider/ethereum-validator-api/pkg/builders"
+	"github.com/powerslider/ethereum-validator-api/pkg/cache"
+	"github.com/powerslider/ethereum-validator-api/pkg/chaintime"
+	"github.com/powerslider/ethereum-validator-api/pkg/config"
+	"github.com/powerslider/ethereum-validator-api/pkg/httpclient"
+	"github.com/powerslider/ethereum-validator-api/pkg/relay"
+	"github.com/powerslider/ethereum-validator-api/pkg/syncduties"
+)
+
+const (
+	outputSQLite = "sqlite"
+	outputJSONL  = "jsonl"
+	dateLayout   = "2006-01-02"
+)
+
+// flags holds the command line options.
+type flags struct {
+	fromDate    string
+	toDate      string
+	output      string
+	out         string
+	checkpoint  string
+	fromSlot    uint64
+	toSlot      uint64
+	rate        float64
+	concurrency int
+	syncDuties  bool
+	retryFailed bool
+	// toSet reports whether -to was given, as its zero default is a valid slot.
+	toSet bool
+}
+
+// Backfill computes the block rewards and sync committees of a historical slot or date range.
+// It uses the same environment (or .env file) as the server for its upstream endpoints.
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx); err != nil {
+		log.Fatalf("[Fatal] %v", err)
+	}
+}
+
+func parseFlags(cfg *config.Config) flags {
+	var f flags
+
+	flag.Uint64Var(&f.fromSlot, "from", 0, "first slot (inclusive)")
+	flag.Uint64Var(&f.toSlot, "to", 0, "last slot (inclusive), required unless -to-date is given")
+	flag.StringVar(&f.fromDate, "from-date", "", "first day (UTC, YYYY-MM-DD), instead of -from")
+	flag.StringVar(&f.toDate, "to-date", "", "last day (UTC, YYYY-MM-DD, inclusive), instead of -to")
+	flag.StringVar(&f.output, "output", outputSQLite, "output format: sqlite or jsonl")
+	flag.StringVar(&f.out, "out", "", "output file (required)")
+	flag.StringVar(&f.checkpoint, "checkpoint", "", "checkpoint file (default <out>.checkpoint)")
+	flag.IntVar(&f.concurrency, "concurrency", cfg.BlockRewardConcurrency, "slots processed in parallel")
+	flag.Float64Var(&f.rate, "rate", 10, "maximum slots started per second (0 for unlimited)")
+	flag.BoolVar(&f.syncDuties, "sync-duties", true, "also fetch the sync committee of every slot")
+	flag.BoolVar(&f.retryFailed, "retry-failed", false, "process the failed slots of the checkpoint again")
+	flag.Parse()
+
+	flag.Visit(func(fl *flag.Flag) {
+		f.toSet = f.toSet || fl.Name == "to"
+	})
+
+	return f
+}
+
+func run(ctx context.Context) error {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return pkgerrors.Wrap(err, "load .env file")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return pkgerrors.Wrap(err, "load config")
+	}
+
+	f := parseFlags(cfg)
+
+	if f.out == "" {
+		return pkgerrors.New("-out is required")
+	}
+
+	if !f.toSet && f.toDate == "" {
+		return pkgerrors.New("-to or -to-date is required")
+	}
+
+	ethClient, err := httpclient.DialExecutionClient(ctx, cfg)
+	if err != nil {
+		return pkgerrors.Wrap(err, "connect to execution client")
+	}
+	defer ethClient.Close()
+
+	beaconCfg, err := cfg.Beacon()
+	if err != nil {
+		return pkgerrors.Wrap(err, "load beacon endpoint config")
+	}
+
+	beaconClient, err := httpclient.New(beaconCfg)
+	if err != nil {
+		return pkgerrors.Wrap(err, "create beacon http client")
+	}
+
+	beaconSvc := beacon.NewService(beaconCfg.URLs, beaconClient)
+	go beaconSvc.RunHealthChecks(ctx, cfg.BeaconHealthCheckInterval)
+
+	clock, err := chaintime.Load(ctx, beaconSvc)
+	if err != nil {
+		return pkgerrors.Wrap(err, "load chain time")
+	}
+
+	opts, err := rangeOptions(f, clock)
+	if err != nil {
+		return err
+	}
+
+	builderRegistry, err := builders.LoadRegistry(cfg.BuilderRegistryFile)
+	if err != nil {
+		return pkgerrors.Wrap(err, "load builder registry")
+	}
+
+	relaySvc, err := relay.NewService(cfg.RelayEndpoints, &http.Client{Timeout: cfg.RelayTimeout})
+	if err != nil {
+		return pkgerrors.Wrap(err, "create relay service")
+	}
+
+	beaconCache := cache.NewBeaconService(beaconSvc, cfg.CacheSize, cfg.CacheTTL)
+	blockRewardSvc := blockreward.NewService(ethClient, beaconCache, clock, builderRegistry, nil)
+	// Relay lookup is disabled without relays, rather than failing every lookup.
+	if len(relaySvc.Relays) > 0 {
+		blockRewardSvc.Relays = relaySvc
+	}
+
+	syncDutySvc := syncduties.NewService(beaconCache, clock)
+	runner := backfill.NewRunner(blockRewardSvc, syncDutySvc, nil)
+
+	switch f.output {
+	case outputSQLite:
+		writer, err := backfill.NewSQLiteWriter(f.out)
+		if err != nil {
+			return err
+		}
+		defer writer.Close()
+
+		runner.Writer = writer
+	case outputJSONL:
+		writer, err := backfill.NewJSONLWriter(f.out)
+		if err != nil {
+			return err
+		}
+		defer writer.Close()
+
+		runner.Writer = writer
+	default:
+		return pkgerrors.Errorf("unknown output %q, expected %s or %s", f.output, outputSQLite, outputJSONL)
+	}
+
+	opts.CheckpointPath = f.checkpoint
+	if opts.CheckpointPath == "" {
+		opts.CheckpointPath = f.out + ".checkpoint"
+	}
+
+	log.Printf("[Start] Backfilling slots %d-%d to %s (%s)", opts.From, opts.To, f.out, f.output)
+
+	cp, err := runner.Run(ctx, opts)
+	if cp != nil {
+		report(cp)
+	}
+
+	if err != nil {
+		return pkgerrors.Wrapf(err, "resume by running the same command again (checkpoint %s)", opts.CheckpointPath)
+	}
+
+	if len(cp.Failures) > 0 {
+		return pkgerrors.Errorf("%d slots failed, retry them by running the same command with -retry-failed", len(cp.Failures))
+	}
+
+	return nil
+}
+
+// rangeOptions resolves the slot range from the slot or date flags.
+func rangeOptions(f flags, clock *chaintime.Clock) (backfill.Options, error) {
+	opts := backfill.Options{
+		From:        f.fromSlot,
+		To:          f.toSlot,
+		Concurrency: f.concurrency,
+		Rate:        f.rate,
+		SyncDuties:  f.syncDuties,
+		RetryFailed: f.retryFailed,
+	}
+
+	if f.fromDate != "" {
+		from, err := time.Parse(dateLayout, f.fromDate)
+		if err != nil {
+			return opts, pkgerrors.Wrap(err, "parse -from-date")
+		}
+
+		if opts.From, err = clock.SlotAt(from); err != nil {
+			return opts, pkgerrors.Wrap(err, "resolve -from-date")
+		}
+
+		// A day starting between two slots begins with the next one.
+		if start, err := clock.SlotStart(opts.From); err == nil && start.Before(from) {
+			opts.From++
+		}
+	}
+
+	if f.toDate != "" {
+		to, err := time.Parse(dateLayout, f.toDate)
+		if err != nil {
+			return opts, pkgerrors.Wrap(err, "parse -to-date")
+		}
+
+		// The last slot of the day is the one in progress just before the next midnight.
+		if opts.To, err = clock.SlotAt(to.AddDate(0, 0, 1).Add(-time.Second)); err != nil {
+			return opts, pkgerrors.Wrap(err, "resolve -to-date")
+		}
+	}
+
+	if opts.From > opts.To {
+		return opts, pkgerrors.Errorf("invalid slot range %d-%d", opts.From, opts.To)
+	}
+
+	if clock.IsFuture(opts.To) {
+		return opts, pkgerrors.Wrapf(beacon.ErrSlotInFuture, "slot %d", opts.To)
+	}
+
+	return opts, nil
+}
+
+// report prints the outcome of a run.
+func report(cp *backfill.Checkpoint) {
+	done := cp.Next - cp.From
+	total := cp.To - cp.From + 1
+
+	fmt.Printf("Processed %d of %d slots (%d-%d), %d failed\n", done, total, cp.From, cp.To, len(cp.Failures))
+
+	for _, failure := range cp.Failures {
+		fmt.Printf("  slot %d: %s\n", failure.Slot, failure.Error)
+	}
+}
@@ -2,18 +2,35 @@ package main
 
 import (
 	"context"
-	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
 	"log"
+	"net/http"
+	"os"
+	"time"
 
 	"github.com/joho/godotenv"
-
-	"github.com/ethereum/go-ethereum/ethclient"
 	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/attesterduties"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
 	"github.com/powerslider/ethereum-validator-api/pkg/blockreward"
+	"github.com/powerslider/ethereum-validator-api/pkg/builders"
+	"github.com/powerslider/ethereum-validator-api/pkg/cache"
+	"github.com/powerslider/ethereum-validator-api/pkg/chaintime"
 	"github.com/powerslider/ethereum-validator-api/pkg/config"
+	"github.com/powerslider/ethereum-validator-api/pkg/events"
 	"github.com/powerslider/ethereum-validator-api/pkg/handlers"
+	"github.com/powerslider/ethereum-validator-api/pkg/health"
+	"github.com/powerslider/ethereum-validator-api/pkg/httpclient"
+	"github.com/powerslider/ethereum-validator-api/pkg/indexer"
+	"github.com/powerslider/ethereum-validator-api/pkg/logging"
+	"github.com/powerslider/ethereum-validator-api/pkg/proposerduties"
+	"github.com/powerslider/ethereum-validator-api/pkg/ratelimit"
+	"github.com/powerslider/ethereum-validator-api/pkg/relay"
 	"github.com/powerslider/ethereum-validator-api/pkg/server"
+	"github.com/powerslider/ethereum-validator-api/pkg/store"
 	"github.com/powerslider/ethereum-validator-api/pkg/syncduties"
+	"github.com/powerslider/ethereum-validator-api/pkg/tracing"
+	"github.com/powerslider/ethereum-validator-api/pkg/validatorrewards"
+	"golang.org/x/sync/semaphore"
 )
 
 // @title Ethereum Validator API
@@ -28,6 +45,11 @@ import (
 // @license.url http://www.apache.org/licenses/LICENSE-2.0.html
 
 // @BasePath /api/v1
+
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description Admin token as "Bearer <token>". The admin endpoints are only served when ADMIN_TOKEN is set.
 func main() {
 	ctx := context.Background()
 
@@ -37,6 +59,9 @@ func main() {
 }
 
 func run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	err := godotenv.Load()
 	if err != nil {
 		return pkgerrors.Wrap(err, "load .env file")
@@ -48,19 +73,185 @@ func run(ctx context.Context) error {
 		return pkgerrors.Wrap(err, "load config")
 	}
 
-	ethClient, err := ethclient.Dial(cfg.RPCEndpoint)
+	if _, err = logging.Setup(os.Stderr, cfg.LogLevel, cfg.LogFormat); err != nil {
+		return pkgerrors.Wrap(err, "set up logging")
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.TracingEndpoint)
+	if err != nil {
+		return pkgerrors.Wrap(err, "set up tracing")
+	}
+
+	defer func() {
+		// The context is canceled by now; flushing pending spans gets a short grace period.
+		flushCtx, flushCancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer flushCancel()
+
+		if err := shutdownTracing(flushCtx); err != nil {
+			log.Printf("[Tracing] flush spans: %v", err)
+		}
+	}()
+
+	ethClient, err := httpclient.DialExecutionClient(ctx, cfg)
 	if err != nil {
 		return pkgerrors.Wrap(err, "connect to execution client")
 	}
 	defer ethClient.Close()
 
+	beaconCfg, err := cfg.Beacon()
+	if err != nil {
+		return pkgerrors.Wrap(err, "load beacon endpoint config")
+	}
+
+	beaconClient, err := httpclient.New(beaconCfg)
+	if err != nil {
+		return pkgerrors.Wrap(err, "create beacon http client")
+	}
+
 	// Initialize services, router and server.
-	beaconSvc := beacon.NewService(cfg.RPCEndpoint)
-	blockRewardSvc := blockreward.NewService(ethClient, beaconSvc)
-	syncDutySvc := syncduties.NewService(beaconSvc)
+	beaconSvc := beacon.NewService(beaconCfg.URLs, beaconClient)
+	if cfg.BeaconMaxInFlight > 0 {
+		beaconSvc.InFlight = semaphore.NewWeighted(cfg.BeaconMaxInFlight)
+	}
+
+	go beaconSvc.RunHealthChecks(ctx, cfg.BeaconHealthCheckInterval)
+
+	clock, err := chaintime.Load(ctx, beaconSvc)
+	if err != nil {
+		return pkgerrors.Wrap(err, "load chain time")
+	}
+
+	// Reward and duty lookups go through the cache; chain time and node status use the nodes directly.
+	beaconCache := cache.NewBeaconService(beaconSvc, cfg.CacheSize, cfg.CacheTTL)
+
+	builderRegistry, err := builders.LoadRegistry(cfg.BuilderRegistryFile)
+	if err != nil {
+		return pkgerrors.Wrap(err, "load builder registry")
+	}
+
+	relaySvc, err := relay.NewService(cfg.RelayEndpoints, &http.Client{Timeout: cfg.RelayTimeout})
+	if err != nil {
+		return pkgerrors.Wrap(err, "create relay service")
+	}
+
+	blockRewardSvc := blockreward.NewService(ethClient, beaconCache, clock, builderRegistry, nil)
+	// Relay lookup is disabled without relays, rather than failing every lookup.
+	if len(relaySvc.Relays) > 0 {
+		blockRewardSvc.Relays = relaySvc
+	}
+
+	blockRewardSvc.MaxRange = cfg.BlockRewardMaxRange
+	blockRewardSvc.Concurrency = cfg.BlockRewardConcurrency
+	syncDutySvc := syncduties.NewService(beaconCache, clock)
+	proposerDutySvc := proposerduties.NewService(beaconSvc, clock)
+	attesterDutySvc := attesterduties.NewService(beaconSvc, clock)
+	validatorRewardSvc := validatorrewards.NewService(beaconSvc, blockRewardSvc, clock)
+	validatorRewardSvc.MaxEpochs = cfg.ValidatorRewardsMaxEpochs
+	validatorRewardSvc.SyncCommittee = beaconCache
+	healthSvc := health.NewService(beaconSvc, ethClient, clock)
+	healthSvc.MaxHeadLag = cfg.ReadyMaxHeadLag
+	healthSvc.Timeout = cfg.ReadyTimeout
+
+	routeLimits, err := ratelimit.ParseRouteLimits(cfg.RateLimitRoutes)
+	if err != nil {
+		return pkgerrors.Wrap(err, "parse RATE_LIMIT_ROUTES")
+	}
+
+	trustedProxies, err := ratelimit.ParseTrustedProxies(cfg.RateLimitTrustedProxies)
+	if err != nil {
+		return pkgerrors.Wrap(err, "parse RATE_LIMIT_TRUSTED_PROXIES")
+	}
+
+	rateLimiter := ratelimit.NewLimiter(
+		ratelimit.Limit{RPS: cfg.RateLimitRPS, Burst: cfg.RateLimitBurst}, routeLimits, cfg.RateLimitMaxClients,
+	)
+	rateLimiter.KeyHeader = cfg.RateLimitKeyHeader
+	rateLimiter.TrustedProxies = trustedProxies
+	rateLimiter.Keys = make(map[string]bool, len(cfg.RateLimitAPIKeys))
+
+	for _, key := range cfg.RateLimitAPIKeys {
+		rateLimiter.Keys[key] = true
+	}
+
+	var progress indexer.ProgressStore
+
+	if cfg.StorePath != "" {
+		var st *store.Store
+
+		st, err = store.Open(cfg.StorePath)
+		if err != nil {
+			return pkgerrors.Wrap(err, "open store")
+		}
+		defer st.Close()
+
+		blockRewardSvc.Store = st
+		syncDutySvc.Store = st
+		progress = st
+	}
+
+	var indexerSvc *indexer.Service
+
+	switch {
+	case cfg.IndexerEnabled && cfg.StorePath == "":
+		// Without a store the precomputed results would not be kept anywhere.
+		log.Println("[Indexer] Not started: INDEXER_ENABLED requires STORE_PATH")
+	case cfg.IndexerEnabled:
+		// The head is read from the nodes directly, the cache would report it late. Head events
+		// drive the indexer; polling once an epoch only covers for lost events.
+		epochDuration := time.Duration(clock.SecondsPerSlot*clock.SlotsPerEpoch) * time.Second //nolint:gosec // minutes
+		indexerSvc = indexer.NewService(blockRewardSvc, syncDutySvc, beaconSvc, progress, epochDuration)
+
+		go indexerSvc.Run(ctx)
+	}
+
+	// Results memoized for recent slots are dropped when a reorg replaces their blocks.
+	reorgs := beacon.NewReorgDetector()
+	reorgs.OnReorg(beaconCache.InvalidateFrom)
+	reorgs.OnReorg(blockRewardSvc.InvalidateFrom)
+	reorgs.OnReorg(syncDutySvc.InvalidateFrom)
+
+	if indexerSvc != nil {
+		reorgs.OnReorg(indexerSvc.Rewind)
+	}
+
+	// A single subscription to the beacon node feeds the reorg detector, the indexer and
+	// the event stream served to clients.
+	eventSvc := events.NewService(beaconSvc, blockRewardSvc)
+	eventSvc.OnEvent(reorgs.HandleEvent)
+
+	if indexerSvc != nil {
+		eventSvc.OnEvent(indexerSvc.HandleHead)
+	}
+
+	// Event streams only end when their subscription is closed, which the server shutdown triggers.
+	eventsCtx, cancelEvents := context.WithCancel(ctx)
+	defer cancelEvents()
+
+	go eventSvc.Run(eventsCtx)
+
+	// The event stream is only served to clients when enabled.
+	var servedEvents *events.Service
+	if cfg.EventsEnabled {
+		servedEvents = eventSvc
+	}
 
-	r := handlers.SetupRouter(blockRewardSvc, syncDutySvc)
+	r := handlers.SetupRouter(handlers.Services{
+		BlockRewards:     blockRewardSvc,
+		SyncDuties:       syncDutySvc,
+		ProposerDuties:   proposerDutySvc,
+		AttesterDuties:   attesterDutySvc,
+		Beacon:           beaconSvc,
+		ValidatorRewards: validatorRewardSvc,
+		Cache:            beaconCache,
+		Builders:         builderRegistry,
+		Clock:            clock,
+		Health:           healthSvc,
+		Indexer:          indexerSvc,
+		Events:           servedEvents,
+		RateLimiter:      rateLimiter,
+	}, cfg.AdminToken)
 	srv := server.NewServer(cfg, r)
+	srv.RegisterOnShutdown(cancelEvents)
 
 	// Run server.
 	if err = srv.Run(ctx); err != nil {
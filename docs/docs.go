@@ -23,33 +23,197 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/blockreward/{slot}": {
+        "/admin/beacon/nodes": {
             "get": {
-                "description": "Retrieves block reward details for a given slot.",
-                "consumes": [
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Lists the configured beacon nodes with their last known health and sync status, most preferred first.\nThe node marked as active is the one requests are currently routed to.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Beacon Node Status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.beaconNodesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/builders": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Lists the known block builders used to attribute MEV blocks.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "BlockReward"
+                    "Admin"
                 ],
-                "summary": "Get Block Reward",
+                "summary": "Get Builder Registry",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.buildersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/builders/reload": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Re-reads the builder registry file. On failure the previously loaded builders are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Reload Builder Registry",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.buildersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/cache": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Reports the size, capacity and hit, miss, eviction and expiry counters of the beacon response cache.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Cache Statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/cache.Stats"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/indexer": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Reports the last slot indexed by the background indexer, the chain head and how far behind it is.\nOnly available when the indexer is enabled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Indexer Status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/indexer.Status"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/attesterduties/{epoch}": {
+            "get": {
+                "description": "Retrieves the attestation committees of an epoch in slot and committee order, with the position\nand validator index of their members. Committees are known up to the epoch after the current one;\nlater epochs are answered with 400. validators restricts the result to the committees of the given\ncomma-separated validator indices (at most 10000), listing only those validators with their public key.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AttesterDuties"
+                ],
+                "summary": "Get Attester Duties",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Slot number",
-                        "name": "slot",
+                        "description": "Epoch number",
+                        "name": "epoch",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated validator indices",
+                        "name": "validators",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.blockRewardResponse"
+                            "$ref": "#/definitions/handlers.attesterDutiesResponse"
                         }
                     },
                     "400": {
@@ -67,9 +231,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/syncduties/{slot}": {
+        "/blockreward": {
             "get": {
-                "description": "Retrieves validators assigned for sync committee duties for a given slot.",
+                "description": "Retrieves block reward details for every slot in [from, to], ordered by slot, with aggregate totals.\nMissed slots are reported inline instead of failing the request.",
                 "consumes": [
                     "application/json"
                 ],
@@ -77,15 +241,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SyncDuties"
+                    "BlockReward"
                 ],
-                "summary": "Get Sync Duties",
+                "summary": "Get Block Rewards For A Slot Range",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Slot number",
-                        "name": "slot",
-                        "in": "path",
+                        "description": "First slot (inclusive)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last slot (inclusive)",
+                        "name": "to",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -93,7 +264,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.syncDutiesResponse"
+                            "$ref": "#/definitions/handlers.blockRewardRangeResponse"
                         }
                     },
                     "400": {
@@ -107,47 +278,1016 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.APIError": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
-                },
-                "details": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                }
-            }
         },
-        "handlers.blockRewardResponse": {
-            "type": "object",
-            "properties": {
-                "reward": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
+        "/blockreward/{id}": {
+            "get": {
+                "description": "Retrieves block reward details for a given block (slot number, 0x-prefixed block root,\nor one of head, genesis, finalized, justified): the consensus-layer reward (Gwei),\nthe execution-layer reward from priority fees or the builder's MEV payment (Wei) and their total.\nUntil finalized, a reorg can replace the block of a slot; block_root identifies the block the reward\nwas computed for. A block root that was reorged out is answered with 409.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BlockReward"
+                ],
+                "summary": "Get Block Reward",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slot number, block root, or head/genesis/finalized/justified",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.blockRewardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
                 }
             }
         },
-        "handlers.syncDutiesResponse": {
-            "type": "object",
-            "properties": {
-                "validators": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+        "/events": {
+            "get": {
+                "description": "Streams chain events as Server-Sent Events. Each event has the topic as its name and JSON data.\nTopics: head, block, finalized_checkpoint and chain_reorg are relayed from the beacon node;\nblock_reward carries the reward of every new block (same format as /blockreward/{id}) once computed.\nClients that fall too far behind are disconnected and should reconnect.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Stream Chain Events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated topics (default: all)",
+                        "name": "topics",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
                     }
                 }
             }
+        },
+        "/proposerduties/{epoch}": {
+            "get": {
+                "description": "Retrieves the validators scheduled to propose the blocks of an epoch, in slot order, with their\nvalidator index and public key. Proposers are known up to the epoch after the current one;\nlater epochs are answered with 400. validators restricts the result to the given comma-separated\nvalidator indices or public keys. A reorg replacing dependent_root reshuffles the proposers.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProposerDuties"
+                ],
+                "summary": "Get Proposer Duties",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Epoch number",
+                        "name": "epoch",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated validator indices or public keys",
+                        "name": "validators",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.proposerDutiesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/syncduties/{id}": {
+            "get": {
+                "description": "Retrieves validators assigned for sync committee duties for a given block (slot number,\n0x-prefixed block root, or one of head, genesis, finalized, justified).\nMembers are listed in committee order with their position, validator index and public key;\na validator occupying several positions is listed once per position.\nUntil finalized, block_root identifies the block the duties were resolved for.\nA block root that was reorged out is answered with 409.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SyncDuties"
+                ],
+                "summary": "Get Sync Duties",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slot number, block root, or head/genesis/finalized/justified",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.syncDutiesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/time/slot": {
+            "get": {
+                "description": "Converts a Unix timestamp (seconds) to the slot in progress at that time,\nwith its epoch, sync committee period and start time.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time"
+                ],
+                "summary": "Get Slot At Time",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unix timestamp in seconds",
+                        "name": "timestamp",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.chainTimeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/time/timestamp": {
+            "get": {
+                "description": "Converts a slot to its start time, epoch and sync committee period.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Time"
+                ],
+                "summary": "Get Slot Timestamp",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot number",
+                        "name": "slot",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.chainTimeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/validators/{id}": {
+            "get": {
+                "description": "Retrieves a validator by index or 0x-prefixed public key: its status, balance and effective balance\n(Gwei), activation and exit epochs, slashed flag and withdrawal credentials.\nBy default the head state is used; state accepts a slot number, state root,\nor one of head, genesis, finalized, justified.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Validators"
+                ],
+                "summary": "Get Validator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Validator index or public key",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State identifier (default: head)",
+                        "name": "state",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.validatorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/validators/{id}/rewards": {
+            "get": {
+                "description": "Retrieves the rewards a validator (index or 0x-prefixed public key) earned in every epoch\nof [from_epoch, to_epoch], ordered by epoch, with totals. Rewards are broken down into\nattestation head, target, source, inclusion delay and inactivity, sync committee and block\nproposal (consensus and execution layer) rewards, in Gwei; penalties are negative.\nProposals list every slot the validator was scheduled to propose, missed ones included.\nAn epoch can be requested once the epoch after it is over.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Validators"
+                ],
+                "summary": "Get Validator Rewards",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Validator index or public key",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "First epoch (inclusive)",
+                        "name": "from_epoch",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last epoch (inclusive)",
+                        "name": "to_epoch",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.validatorRewardsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "beacon.NodeStatus": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active marks the node requests are currently routed to first.",
+                    "type": "boolean"
+                },
+                "el_offline": {
+                    "type": "boolean"
+                },
+                "head_slot": {
+                    "type": "integer"
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "is_optimistic": {
+                    "type": "boolean"
+                },
+                "is_syncing": {
+                    "type": "boolean"
+                },
+                "last_checked": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "sync_distance": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "builders.Builder": {
+            "type": "object",
+            "properties": {
+                "extra_data_patterns": {
+                    "description": "ExtraDataPatterns are regular expressions matched against the block's extra data.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "fee_recipients": {
+                    "description": "FeeRecipients are the execution addresses the builder sets as block fee recipient.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pubkeys": {
+                    "description": "Pubkeys are the BLS public keys the builder signs relay bids with.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "cache.Stats": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "entries": {
+                    "type": "integer"
+                },
+                "evictions": {
+                    "type": "integer"
+                },
+                "expired": {
+                    "type": "integer"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "invalidated": {
+                    "description": "Invalidated counts the entries removed because they became stale, e.g. after a reorg.",
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.attestationCommitteeMemberResponse": {
+            "type": "object",
+            "properties": {
+                "position": {
+                    "type": "integer"
+                },
+                "pubkey": {
+                    "description": "Pubkey is only set for requests filtered by validators.",
+                    "type": "string"
+                },
+                "validator_index": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.attestationCommitteeResponse": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "integer"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.attestationCommitteeMemberResponse"
+                    }
+                },
+                "slot": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.attesterDutiesResponse": {
+            "type": "object",
+            "properties": {
+                "committees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.attestationCommitteeResponse"
+                    }
+                },
+                "epoch": {
+                    "type": "integer"
+                },
+                "finalized": {
+                    "description": "Finalized is false while a reorg can still reshuffle the committees.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.beaconNodesResponse": {
+            "type": "object",
+            "properties": {
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/beacon.NodeStatus"
+                    }
+                }
+            }
+        },
+        "handlers.blockRewardRangeResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer"
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.blockRewardSlotResponse"
+                    }
+                },
+                "to": {
+                    "type": "integer"
+                },
+                "totals": {
+                    "$ref": "#/definitions/handlers.blockRewardTotalsResponse"
+                }
+            }
+        },
+        "handlers.blockRewardResponse": {
+            "type": "object",
+            "properties": {
+                "block_root": {
+                    "type": "string"
+                },
+                "builder": {
+                    "type": "string"
+                },
+                "builder_pubkey": {
+                    "type": "string"
+                },
+                "consensus_reward_gwei": {
+                    "type": "string"
+                },
+                "execution_reward_wei": {
+                    "type": "string"
+                },
+                "finalized": {
+                    "description": "Finalized is false while a reorg can still replace the block at BlockRoot.",
+                    "type": "boolean"
+                },
+                "mev_bid_value_wei": {
+                    "type": "string"
+                },
+                "mev_payment_tx": {
+                    "type": "string"
+                },
+                "mev_payment_wei": {
+                    "type": "string"
+                },
+                "priority_fees_wei": {
+                    "type": "string"
+                },
+                "relay_checked": {
+                    "description": "RelayChecked is false when Status was not confirmed by the relays, because none are\nconfigured or some could not be queried.",
+                    "type": "boolean"
+                },
+                "relays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reward": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_reward_gwei": {
+                    "type": "string"
+                },
+                "total_reward_wei": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.blockRewardSlotResponse": {
+            "type": "object",
+            "properties": {
+                "block_root": {
+                    "type": "string"
+                },
+                "builder": {
+                    "type": "string"
+                },
+                "builder_pubkey": {
+                    "type": "string"
+                },
+                "consensus_reward_gwei": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "execution_reward_wei": {
+                    "type": "string"
+                },
+                "finalized": {
+                    "description": "Finalized is false while a reorg can still replace the block at BlockRoot.",
+                    "type": "boolean"
+                },
+                "mev_bid_value_wei": {
+                    "type": "string"
+                },
+                "mev_payment_tx": {
+                    "type": "string"
+                },
+                "mev_payment_wei": {
+                    "type": "string"
+                },
+                "missed": {
+                    "type": "boolean"
+                },
+                "priority_fees_wei": {
+                    "type": "string"
+                },
+                "relay_checked": {
+                    "description": "RelayChecked is false when Status was not confirmed by the relays, because none are\nconfigured or some could not be queried.",
+                    "type": "boolean"
+                },
+                "relays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reward": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_reward_gwei": {
+                    "type": "string"
+                },
+                "total_reward_wei": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.blockRewardTotalsResponse": {
+            "type": "object",
+            "properties": {
+                "consensus_reward_gwei": {
+                    "type": "string"
+                },
+                "execution_reward_wei": {
+                    "type": "string"
+                },
+                "mev_blocks": {
+                    "type": "integer"
+                },
+                "missed_slots": {
+                    "type": "integer"
+                },
+                "proposed_blocks": {
+                    "type": "integer"
+                },
+                "total_reward_gwei": {
+                    "type": "string"
+                },
+                "total_reward_wei": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.buildersResponse": {
+            "type": "object",
+            "properties": {
+                "builders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/builders.Builder"
+                    }
+                }
+            }
+        },
+        "handlers.chainTimeResponse": {
+            "type": "object",
+            "properties": {
+                "epoch": {
+                    "type": "integer"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "sync_committee_period": {
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.proposalRewardResponse": {
+            "type": "object",
+            "properties": {
+                "block_root": {
+                    "type": "string"
+                },
+                "consensus_reward_gwei": {
+                    "type": "string"
+                },
+                "execution_reward_gwei": {
+                    "type": "string"
+                },
+                "missed": {
+                    "type": "boolean"
+                },
+                "slot": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.proposerDutiesResponse": {
+            "type": "object",
+            "properties": {
+                "dependent_root": {
+                    "type": "string"
+                },
+                "epoch": {
+                    "type": "integer"
+                },
+                "proposers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.proposerDutyResponse"
+                    }
+                }
+            }
+        },
+        "handlers.proposerDutyResponse": {
+            "type": "object",
+            "properties": {
+                "pubkey": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "validator_index": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.rewardBreakdownResponse": {
+            "type": "object",
+            "properties": {
+                "attestation_head_gwei": {
+                    "type": "string"
+                },
+                "attestation_inactivity_gwei": {
+                    "type": "string"
+                },
+                "attestation_inclusion_delay_gwei": {
+                    "type": "string"
+                },
+                "attestation_source_gwei": {
+                    "type": "string"
+                },
+                "attestation_target_gwei": {
+                    "type": "string"
+                },
+                "proposal_consensus_gwei": {
+                    "type": "string"
+                },
+                "proposal_execution_gwei": {
+                    "type": "string"
+                },
+                "sync_committee_gwei": {
+                    "type": "string"
+                },
+                "total_gwei": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.syncCommitteeMemberResponse": {
+            "type": "object",
+            "properties": {
+                "position": {
+                    "type": "integer"
+                },
+                "pubkey": {
+                    "type": "string"
+                },
+                "validator_index": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.syncDutiesResponse": {
+            "type": "object",
+            "properties": {
+                "block_root": {
+                    "type": "string"
+                },
+                "finalized": {
+                    "description": "Finalized is false while a reorg can still replace the block at BlockRoot.",
+                    "type": "boolean"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.syncCommitteeMemberResponse"
+                    }
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "validators": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.validatorEpochRewardsResponse": {
+            "type": "object",
+            "properties": {
+                "epoch": {
+                    "type": "integer"
+                },
+                "proposals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.proposalRewardResponse"
+                    }
+                },
+                "rewards": {
+                    "$ref": "#/definitions/handlers.rewardBreakdownResponse"
+                },
+                "sync_committee": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.validatorResponse": {
+            "type": "object",
+            "properties": {
+                "activation_eligibility_epoch": {
+                    "type": "integer"
+                },
+                "activation_epoch": {
+                    "type": "integer"
+                },
+                "balance_gwei": {
+                    "type": "string"
+                },
+                "effective_balance_gwei": {
+                    "type": "string"
+                },
+                "exit_epoch": {
+                    "type": "integer"
+                },
+                "finalized": {
+                    "type": "boolean"
+                },
+                "index": {
+                    "type": "string"
+                },
+                "pubkey": {
+                    "type": "string"
+                },
+                "slashed": {
+                    "type": "boolean"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "withdrawable_epoch": {
+                    "type": "integer"
+                },
+                "withdrawal_credentials": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.validatorRewardsResponse": {
+            "type": "object",
+            "properties": {
+                "epochs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.validatorEpochRewardsResponse"
+                    }
+                },
+                "from_epoch": {
+                    "type": "integer"
+                },
+                "to_epoch": {
+                    "type": "integer"
+                },
+                "totals": {
+                    "$ref": "#/definitions/handlers.rewardBreakdownResponse"
+                },
+                "validator_index": {
+                    "type": "string"
+                }
+            }
+        },
+        "indexer.Status": {
+            "type": "object",
+            "properties": {
+                "head_slot": {
+                    "type": "integer"
+                },
+                "lag": {
+                    "description": "Lag is the number of slots the indexer is behind the head.",
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_indexed_slot": {
+                    "description": "LastIndexedSlot is the highest slot whose reward and duties were computed.",
+                    "type": "integer"
+                },
+                "running": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "Admin token as \"Bearer \u003ctoken\u003e\". The admin endpoints are only served when ADMIN_TOKEN is set.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
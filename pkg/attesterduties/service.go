@@ -0,0 +1,166 @@
+package attesterduties
+
+import (
+	"context"
+	"errors"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+)
+
+// DefaultMaxValidators is the default maximum number of validators a request may filter by.
+const DefaultMaxValidators = 10_000
+
+// Lookahead is the number of epochs after the current one whose committees are known.
+const Lookahead = 1
+
+var (
+	ErrEpochBeyondLookahead = errors.New("epoch is beyond the attester lookahead")
+	ErrTooManyValidators    = errors.New("too many validators requested")
+)
+
+type BeaconService interface {
+	GetCommittees(ctx context.Context, state beacon.StateID, epoch uint64) (*beacon.CommitteesResponse, error)
+	FetchValidatorsByIDs(ctx context.Context, state beacon.StateID, ids []string) ([]beacon.ValidatorEntry, error)
+}
+
+// Clock converts between slots and epochs. It is satisfied by *chaintime.Clock.
+type Clock interface {
+	CurrentSlot() uint64
+	EpochOf(slot uint64) uint64
+	EpochStartSlot(epoch uint64) uint64
+}
+
+// Member is a validator of an attestation committee and its position in the committee,
+// which is its bit in the aggregation bits of the committee's attestations.
+type Member struct {
+	ValidatorIndex string
+	// Pubkey is only resolved for the validators a request is filtered by.
+	Pubkey   string
+	Position int
+}
+
+// Committee is an attestation committee of an epoch.
+type Committee struct {
+	Members []Member
+	Index   uint64
+	Slot    uint64
+}
+
+// Duties holds the attestation committees of an epoch, in slot and committee order.
+type Duties struct {
+	Committees []Committee
+	Epoch      uint64
+	// Finalized duties can no longer change. Others may, after a reorg.
+	Finalized bool
+}
+
+type Service struct {
+	BeaconService BeaconService
+	Clock         Clock
+	// MaxValidators caps the validators a request may filter by (DefaultMaxValidators when zero).
+	MaxValidators int
+}
+
+func NewService(svc BeaconService, clock Clock) *Service {
+	return &Service{
+		BeaconService: svc,
+		Clock:         clock,
+		MaxValidators: DefaultMaxValidators,
+	}
+}
+
+// GetAttesterDuties returns the attestation committees of an epoch with the indices of their members.
+// When validators (indices) are given, only the committees of those validators are returned, each
+// restricted to them, and their public keys are resolved too. Returns ErrEpochBeyondLookahead for
+// epochs after the next one and ErrTooManyValidators for more than MaxValidators validators.
+func (s *Service) GetAttesterDuties(ctx context.Context, epoch uint64, validators []string) (*Duties, error) {
+	maxValidators := s.MaxValidators
+	if maxValidators <= 0 {
+		maxValidators = DefaultMaxValidators
+	}
+
+	if len(validators) > maxValidators {
+		return nil, pkgerrors.Wrapf(ErrTooManyValidators, "%d validators, at most %d allowed", len(validators), maxValidators)
+	}
+
+	current := s.Clock.CurrentSlot()
+	if epoch > s.Clock.EpochOf(current)+Lookahead {
+		return nil, pkgerrors.Wrapf(ErrEpochBeyondLookahead,
+			"epoch %d, current epoch is %d", epoch, s.Clock.EpochOf(current))
+	}
+
+	// Past committees are read from the state at the start of their epoch, upcoming ones from the head.
+	state := beacon.StateID(beacon.BlockHead)
+	if start := s.Clock.EpochStartSlot(epoch); start <= current {
+		state = beacon.SlotStateID(start)
+	}
+
+	resp, err := s.BeaconService.GetCommittees(ctx, state, epoch)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch committees")
+	}
+
+	wanted := make(map[string]bool, len(validators))
+	for _, id := range validators {
+		wanted[id] = true
+	}
+
+	committees := make([]Committee, 0, len(resp.Data))
+	ids := make([]string, 0)
+
+	for _, c := range resp.Data {
+		committee := Committee{Index: c.Index, Slot: c.Slot}
+
+		for position, index := range c.Validators {
+			if len(wanted) > 0 && !wanted[index] {
+				continue
+			}
+
+			committee.Members = append(committee.Members, Member{Position: position, ValidatorIndex: index})
+			ids = append(ids, index)
+		}
+
+		if len(committee.Members) > 0 {
+			committees = append(committees, committee)
+		}
+	}
+
+	// Every active validator is in a committee of each epoch; resolving them all would take
+	// hundreds of upstream requests, so unfiltered members are listed by index only.
+	if len(wanted) > 0 {
+		if err = s.resolvePubkeys(ctx, state, committees, ids); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Duties{
+		Epoch:      epoch,
+		Committees: committees,
+		Finalized:  resp.Finalized,
+	}, nil
+}
+
+// resolvePubkeys fills in the public key of every member. ids lists the member indices in committee order.
+func (s *Service) resolvePubkeys(ctx context.Context, state beacon.StateID, committees []Committee, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	validators, err := s.BeaconService.FetchValidatorsByIDs(ctx, state, ids)
+	if err != nil {
+		return pkgerrors.Wrap(err, "fetch validators by ids")
+	}
+
+	// FetchValidatorsByIDs keeps the order of ids, which is the order of the members.
+	next := 0
+
+	for i := range committees {
+		for j := range committees[i].Members {
+			committees[i].Members[j].Pubkey = validators[next].Validator.Pubkey
+			next++
+		}
+	}
+
+	return nil
+}
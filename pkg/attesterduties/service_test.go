@@ -0,0 +1,115 @@
+package attesterduties_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/powerslider/ethereum-validator-api/pkg/attesterduties"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/chaintime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock uses the mainnet genesis time and spec.
+var testClock = chaintime.NewClock(time.Unix(1606824023, 0), 12, 32, 256)
+
+// mockBeaconService answers with two committees at the first slot of the epoch
+// and records the state the committees were read from.
+type mockBeaconService struct {
+	states chan beacon.StateID
+}
+
+func (m *mockBeaconService) GetCommittees(
+	ctx context.Context,
+	state beacon.StateID,
+	epoch uint64,
+) (*beacon.CommitteesResponse, error) {
+	m.states <- state
+
+	return &beacon.CommitteesResponse{
+		Finalized: true,
+		Data: []beacon.Committee{
+			{Index: 0, Slot: epoch * 32, Validators: []string{"5", "42", "9"}},
+			{Index: 1, Slot: epoch * 32, Validators: []string{"7", "1"}},
+		},
+	}, nil
+}
+
+func (m *mockBeaconService) FetchValidatorsByIDs(
+	ctx context.Context,
+	state beacon.StateID,
+	ids []string,
+) ([]beacon.ValidatorEntry, error) {
+	validators := make([]beacon.ValidatorEntry, len(ids))
+	for i, id := range ids {
+		validators[i] = beacon.ValidatorEntry{Index: id, Validator: beacon.ValidatorInfo{Pubkey: "0xpk" + id}}
+	}
+
+	return validators, nil
+}
+
+func TestGetAttesterDuties(t *testing.T) {
+	t.Parallel()
+
+	current := testClock.EpochOf(testClock.CurrentSlot())
+
+	t.Run("all committees", func(t *testing.T) {
+		t.Parallel()
+
+		beaconSvc := &mockBeaconService{states: make(chan beacon.StateID, 1)}
+		svc := attesterduties.NewService(beaconSvc, testClock)
+
+		duties, err := svc.GetAttesterDuties(context.Background(), 10, nil)
+		require.NoError(t, err)
+		assert.Equal(t, beacon.SlotStateID(320), <-beaconSvc.states)
+		assert.True(t, duties.Finalized)
+		require.Len(t, duties.Committees, 2)
+		require.Len(t, duties.Committees[1].Members, 2)
+		// Public keys are not resolved for the whole validator set.
+		assert.Equal(t, attesterduties.Member{ValidatorIndex: "1", Position: 1}, duties.Committees[1].Members[1])
+	})
+
+	t.Run("filtered by validator", func(t *testing.T) {
+		t.Parallel()
+
+		beaconSvc := &mockBeaconService{states: make(chan beacon.StateID, 1)}
+		svc := attesterduties.NewService(beaconSvc, testClock)
+
+		duties, err := svc.GetAttesterDuties(context.Background(), current+1, []string{"42"})
+		require.NoError(t, err)
+		// The next epoch has not started, its committees are read from the head state.
+		assert.Equal(t, beacon.StateID(beacon.BlockHead), <-beaconSvc.states)
+		require.Len(t, duties.Committees, 1)
+		assert.Equal(t, []attesterduties.Member{{ValidatorIndex: "42", Pubkey: "0xpk42", Position: 1}},
+			duties.Committees[0].Members)
+	})
+
+	t.Run("too many validators", func(t *testing.T) {
+		t.Parallel()
+
+		beaconSvc := &mockBeaconService{states: make(chan beacon.StateID, 1)}
+		svc := attesterduties.NewService(beaconSvc, testClock)
+		svc.MaxValidators = 1
+
+		_, err := svc.GetAttesterDuties(context.Background(), 10, []string{"42", "7"})
+		require.ErrorIs(t, err, attesterduties.ErrTooManyValidators)
+
+		// The committees of the whole validator set are not limited.
+		svc.MaxValidators = 4
+
+		duties, err := svc.GetAttesterDuties(context.Background(), 10, nil)
+		require.NoError(t, err)
+		assert.Len(t, duties.Committees, 2)
+	})
+
+	t.Run("beyond lookahead", func(t *testing.T) {
+		t.Parallel()
+
+		svc := attesterduties.NewService(&mockBeaconService{}, testClock)
+
+		_, err := svc.GetAttesterDuties(context.Background(), current+2, nil)
+		require.ErrorIs(t, err, attesterduties.ErrEpochBeyondLookahead)
+	})
+}
@@ -0,0 +1,352 @@
+package backfill
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"math"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/blockreward"
+	"github.com/powerslider/ethereum-validator-api/pkg/syncduties"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+// checkpointInterval is how often progress is written to the checkpoint file.
+const checkpointInterval = time.Second
+
+var (
+	ErrCheckpointMismatch = errors.New("checkpoint belongs to a different slot range")
+	ErrInvalidRange       = errors.New("invalid slot range")
+)
+
+// BlockRewardService computes the block reward of a slot.
+type BlockRewardService interface {
+	GetBlockReward(ctx context.Context, id beacon.BlockID) (*blockreward.Result, error)
+}
+
+// SyncDutyService computes the sync committee of a slot.
+type SyncDutyService interface {
+	GetSyncDuties(ctx context.Context, id beacon.BlockID) (*syncduties.Duties, error)
+}
+
+// Writer receives the record of every processed slot. It must be safe for concurrent use.
+type Writer interface {
+	Write(rec Record) error
+}
+
+// Record is the outcome of a single slot.
+type Record struct {
+	BlockReward *blockreward.Result `json:"block_reward,omitempty"`
+	SyncDuties  *syncduties.Duties  `json:"sync_duties,omitempty"`
+	Slot        uint64              `json:"slot"`
+	// Missed is set for slots without a block. Their sync duties are still recorded.
+	Missed bool `json:"missed"`
+}
+
+// Failure is a slot that could not be processed.
+type Failure struct {
+	Error string `json:"error"`
+	Slot  uint64 `json:"slot"`
+}
+
+// Checkpoint is the persisted progress of a backfill. Every slot before Next was processed.
+type Checkpoint struct {
+	Failures []Failure `json:"failures"`
+	From     uint64    `json:"from"`
+	To       uint64    `json:"to"`
+	Next     uint64    `json:"next"`
+}
+
+// Options configures a backfill run.
+type Options struct {
+	// CheckpointPath is the file progress is saved to and resumed from; empty disables checkpointing.
+	CheckpointPath string
+	From           uint64
+	To             uint64
+	Concurrency    int
+	// Rate is the maximum number of slots started per second; zero means unlimited.
+	Rate float64
+	// SyncDuties also fetches the sync committee of every slot.
+	SyncDuties bool
+	// RetryFailed processes the failed slots of the checkpoint again before continuing the range.
+	RetryFailed bool
+}
+
+// Runner fetches the block rewards and sync duties of a slot range.
+type Runner struct {
+	BlockRewards BlockRewardService
+	SyncDuties   SyncDutyService
+	// Writer is optional; without it records are discarded.
+	Writer Writer
+}
+
+// NewRunner creates a new backfill runner.
+func NewRunner(rewards BlockRewardService, duties SyncDutyService, writer Writer) *Runner {
+	return &Runner{
+		BlockRewards: rewards,
+		SyncDuties:   duties,
+		Writer:       writer,
+	}
+}
+
+// Run processes every slot of the range, resuming from the checkpoint when there is one.
+// Slot failures do not stop the run; they are returned in the checkpoint, and processed again
+// by a run with RetryFailed. When ctx is canceled, the progress so far is saved and the context
+// error is returned along with the checkpoint. Returns ErrInvalidRange when From is after To, or
+// To is the largest slot, which the checkpoint cannot advance past.
+func (r *Runner) Run(ctx context.Context, opts Options) (*Checkpoint, error) {
+	if opts.From > opts.To || opts.To == math.MaxUint64 {
+		return nil, pkgerrors.Wrapf(ErrInvalidRange, "slots %d-%d", opts.From, opts.To)
+	}
+
+	cp, err := loadCheckpoint(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := newProgress(cp)
+
+	limit := rate.Inf
+	if opts.Rate > 0 {
+		limit = rate.Limit(opts.Rate)
+	}
+
+	limiter := rate.NewLimiter(limit, 1)
+
+	var g errgroup.Group
+	g.SetLimit(max(opts.Concurrency, 1))
+
+	saveCtx, stopSaving := context.WithCancel(ctx)
+	saved := make(chan struct{})
+
+	go func() {
+		defer close(saved)
+		saveEvery(saveCtx, opts.CheckpointPath, progress)
+	}()
+
+	var retries []uint64
+
+	if opts.RetryFailed {
+		for _, failure := range cp.Failures {
+			retries = append(retries, failure.Slot)
+		}
+	}
+
+	for slot := range slotsToProcess(retries, cp.Next, opts.To) {
+		if err = limiter.Wait(ctx); err != nil {
+			break
+		}
+
+		g.Go(func() error {
+			err := r.processSlot(ctx, slot, opts.SyncDuties)
+
+			// Interrupted slots are not done; they are processed again on resume.
+			if ctx.Err() == nil {
+				progress.done(slot, err)
+			}
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	stopSaving()
+	<-saved
+
+	final := progress.checkpoint()
+	if err = saveCheckpoint(opts.CheckpointPath, final); err != nil {
+		return final, err
+	}
+
+	if err = ctx.Err(); err != nil {
+		return final, pkgerrors.Wrap(err, "backfill interrupted")
+	}
+
+	return final, nil
+}
+
+// slotsToProcess yields the retried slots, then the slots from next to to.
+func slotsToProcess(retries []uint64, next, to uint64) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		for _, slot := range retries {
+			if !yield(slot) {
+				return
+			}
+		}
+
+		if next > to {
+			return
+		}
+
+		// Stop at to rather than incrementing past it, which would wrap at the largest slot.
+		for slot := next; ; slot++ {
+			if !yield(slot) || slot == to {
+				return
+			}
+		}
+	}
+}
+
+// processSlot fetches and writes the record of a slot.
+func (r *Runner) processSlot(ctx context.Context, slot uint64, withDuties bool) error {
+	id := beacon.SlotBlockID(slot)
+	rec := Record{Slot: slot}
+
+	result, err := r.BlockRewards.GetBlockReward(ctx, id)
+
+	switch cause := pkgerrors.Cause(err); {
+	case err == nil:
+		rec.BlockReward = result
+	case errors.Is(cause, beacon.ErrSlotMissedOrDoesNotExist):
+		rec.Missed = true
+	case errors.Is(cause, beacon.ErrNoExecutionPayload):
+		// Pre-Merge slot, there is no reward to record.
+	default:
+		return pkgerrors.Wrap(err, "fetch block reward")
+	}
+
+	if withDuties {
+		duties, err := r.SyncDuties.GetSyncDuties(ctx, id)
+
+		switch cause := pkgerrors.Cause(err); {
+		case err == nil:
+			rec.SyncDuties = duties
+		case errors.Is(cause, beacon.ErrSlotWasMissed), errors.Is(cause, beacon.ErrDutiesNotFound):
+			// Pre-Altair slot, there is no sync committee.
+		default:
+			return pkgerrors.Wrap(err, "fetch sync duties")
+		}
+	}
+
+	if r.Writer == nil {
+		return nil
+	}
+
+	return pkgerrors.Wrap(r.Writer.Write(rec), "write record")
+}
+
+// saveEvery periodically saves the checkpoint until ctx is canceled.
+func saveEvery(ctx context.Context, path string, progress *progress) {
+	ticker := time.NewTicker(checkpointInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// A failed intermediate save is retried on the next tick and at the end of the run.
+			_ = saveCheckpoint(path, progress.checkpoint())
+		}
+	}
+}
+
+// progress tracks the slots completed out of order by concurrent workers.
+type progress struct {
+	completed map[uint64]bool
+	cp        Checkpoint
+	mu        sync.Mutex
+}
+
+func newProgress(cp Checkpoint) *progress {
+	return &progress{cp: cp, completed: make(map[uint64]bool)}
+}
+
+// done marks slot as processed and advances Next past all contiguous processed slots.
+// A retried slot replaces its previous failure.
+func (p *progress) done(slot uint64, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.cp.Failures = slices.DeleteFunc(p.cp.Failures, func(f Failure) bool { return f.Slot == slot })
+
+	if err != nil {
+		p.cp.Failures = append(p.cp.Failures, Failure{Slot: slot, Error: err.Error()})
+	}
+
+	// Retried slots are behind Next already.
+	if slot < p.cp.Next {
+		return
+	}
+
+	p.completed[slot] = true
+
+	for p.completed[p.cp.Next] {
+		delete(p.completed, p.cp.Next)
+		p.cp.Next++
+	}
+}
+
+// checkpoint returns a copy of the current checkpoint with failures ordered by slot.
+func (p *progress) checkpoint() *Checkpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cp := p.cp
+	cp.Failures = slices.Clone(p.cp.Failures)
+	slices.SortFunc(cp.Failures, func(a, b Failure) int {
+		return cmp.Compare(a.Slot, b.Slot)
+	})
+
+	return &cp
+}
+
+// loadCheckpoint returns the saved checkpoint for the range, or a fresh one.
+// Returns ErrCheckpointMismatch when the saved checkpoint is for another range.
+func loadCheckpoint(opts Options) (Checkpoint, error) {
+	fresh := Checkpoint{From: opts.From, To: opts.To, Next: opts.From}
+
+	if opts.CheckpointPath == "" {
+		return fresh, nil
+	}
+
+	data, err := os.ReadFile(opts.CheckpointPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return fresh, nil
+	}
+
+	if err != nil {
+		return Checkpoint{}, pkgerrors.Wrap(err, "read checkpoint")
+	}
+
+	var cp Checkpoint
+	if err = json.Unmarshal(data, &cp); err != nil {
+		return Checkpoint{}, pkgerrors.Wrap(err, "parse checkpoint")
+	}
+
+	if cp.From != opts.From || cp.To != opts.To {
+		return Checkpoint{}, pkgerrors.Wrapf(ErrCheckpointMismatch,
+			"%s is for slots %d-%d", opts.CheckpointPath, cp.From, cp.To)
+	}
+
+	return cp, nil
+}
+
+// saveCheckpoint atomically replaces the checkpoint file.
+func saveCheckpoint(path string, cp *Checkpoint) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return pkgerrors.Wrap(err, "encode checkpoint")
+	}
+
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		return pkgerrors.Wrap(err, "write checkpoint")
+	}
+
+	return pkgerrors.Wrap(os.Rename(tmp, path), "replace checkpoint")
+}
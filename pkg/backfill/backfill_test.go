@@ -0,0 +1,212 @@
+package backfill_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/backfill"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/blockreward"
+	"github.com/powerslider/ethereum-validator-api/pkg/syncduties"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockServices struct {
+	records []backfill.Record
+	mu      sync.Mutex
+}
+
+func (m *mockServices) GetBlockReward(ctx context.Context, id beacon.BlockID) (*blockreward.Result, error) {
+	slot, _ := id.Slot()
+
+	switch slot {
+	case 12:
+		return nil, pkgerrors.Wrap(beacon.ErrSlotMissedOrDoesNotExist, "fetch block header")
+	case 14:
+		return nil, errors.New("upstream unavailable")
+	}
+
+	return &blockreward.Result{Slot: slot}, nil
+}
+
+func (m *mockServices) GetSyncDuties(ctx context.Context, id beacon.BlockID) (*syncduties.Duties, error) {
+	slot, _ := id.Slot()
+
+	return &syncduties.Duties{Slot: slot}, nil
+}
+
+func (m *mockServices) Write(rec backfill.Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.records = append(m.records, rec)
+
+	return nil
+}
+
+func (m *mockServices) slots() []uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	slots := make([]uint64, 0, len(m.records))
+	for _, rec := range m.records {
+		slots = append(slots, rec.Slot)
+	}
+
+	slices.Sort(slots)
+
+	return slots
+}
+
+func TestRunReportsFailures(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockServices{}
+	runner := backfill.NewRunner(mock, mock, mock)
+
+	cp, err := runner.Run(context.Background(), backfill.Options{
+		CheckpointPath: filepath.Join(t.TempDir(), "checkpoint"),
+		From:           10,
+		To:             15,
+		Concurrency:    3,
+		SyncDuties:     true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(16), cp.Next)
+	require.Len(t, cp.Failures, 1)
+	assert.Equal(t, uint64(14), cp.Failures[0].Slot)
+	assert.Contains(t, cp.Failures[0].Error, "upstream unavailable")
+
+	// The missed slot is recorded, the failed one is not.
+	assert.Equal(t, []uint64{10, 11, 12, 13, 15}, mock.slots())
+}
+
+func TestRunResumesFromCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	require.NoError(t, os.WriteFile(path, []byte(`{"from":10,"to":13,"next":12,"failures":[{"slot":11,"error":"boom"}]}`), 0o600))
+
+	mock := &mockServices{}
+	runner := backfill.NewRunner(mock, mock, mock)
+
+	cp, err := runner.Run(context.Background(), backfill.Options{CheckpointPath: path, From: 10, To: 13})
+	require.NoError(t, err)
+
+	assert.Equal(t, []uint64{12, 13}, mock.slots())
+	assert.Equal(t, uint64(14), cp.Next)
+	assert.Equal(t, []backfill.Failure{{Slot: 11, Error: "boom"}}, cp.Failures)
+
+	_, err = runner.Run(context.Background(), backfill.Options{CheckpointPath: path, From: 0, To: 13})
+	assert.ErrorIs(t, err, backfill.ErrCheckpointMismatch)
+}
+
+func TestRunRetriesFailed(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	require.NoError(t, os.WriteFile(path, []byte(
+		`{"from":10,"to":15,"next":16,"failures":[{"slot":11,"error":"boom"},{"slot":14,"error":"boom"}]}`), 0o600))
+
+	mock := &mockServices{}
+	runner := backfill.NewRunner(mock, mock, mock)
+
+	cp, err := runner.Run(context.Background(), backfill.Options{CheckpointPath: path, From: 10, To: 15, RetryFailed: true})
+	require.NoError(t, err)
+
+	// Slot 11 succeeds this time, slot 14 fails again.
+	assert.Equal(t, []uint64{11}, mock.slots())
+	assert.Equal(t, uint64(16), cp.Next)
+	require.Len(t, cp.Failures, 1)
+	assert.Equal(t, uint64(14), cp.Failures[0].Slot)
+	assert.Contains(t, cp.Failures[0].Error, "upstream unavailable")
+}
+
+func TestRunRejectsInvalidRange(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockServices{}
+	runner := backfill.NewRunner(mock, mock, mock)
+
+	for _, opts := range []backfill.Options{
+		{From: 11, To: 10},
+		{From: math.MaxUint64 - 1, To: math.MaxUint64},
+	} {
+		_, err := runner.Run(context.Background(), opts)
+		assert.ErrorIs(t, err, backfill.ErrInvalidRange)
+	}
+
+	assert.Empty(t, mock.slots())
+}
+
+func TestJSONLWriterResume(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "out.jsonl")
+
+	writer, err := backfill.NewJSONLWriter(path)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(backfill.Record{Slot: 10}))
+	require.NoError(t, writer.Write(backfill.Record{Slot: 11, Missed: true}))
+	require.NoError(t, writer.Close())
+
+	// The run was killed while writing slot 12.
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"slot":12,"mis`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	writer, err = backfill.NewJSONLWriter(path)
+	require.NoError(t, err)
+
+	for _, slot := range []uint64{11, 12} {
+		require.NoError(t, writer.Write(backfill.Record{Slot: slot}))
+	}
+
+	require.NoError(t, writer.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"slot\":10,\"missed\":false}\n{\"slot\":11,\"missed\":true}\n{\"slot\":12,\"missed\":false}\n", string(data))
+}
+
+func TestSQLiteWriter(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "out.db")
+
+	writer, err := backfill.NewSQLiteWriter(path)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(backfill.Record{Slot: 10, BlockReward: &blockreward.Result{Slot: 10, Status: "mev"}}))
+	require.NoError(t, writer.Write(backfill.Record{Slot: 11, Missed: true}))
+	// Written again on resume.
+	require.NoError(t, writer.Write(backfill.Record{Slot: 11, Missed: true}))
+	require.NoError(t, writer.Close())
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM records`).Scan(&count))
+	assert.Equal(t, 2, count)
+
+	var status string
+	require.NoError(t, db.QueryRow(`SELECT block_reward ->> '$.Status' FROM records WHERE slot = 10`).Scan(&status))
+	assert.Equal(t, "mev", status)
+
+	var blockReward sql.NullString
+	require.NoError(t, db.QueryRow(`SELECT block_reward FROM records WHERE slot = 11 AND missed`).Scan(&blockReward))
+	assert.False(t, blockReward.Valid)
+}
@@ -0,0 +1,101 @@
+package backfill
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// JSONLWriter appends one JSON record per line to a file. Records are written as slots
+// complete, so they are not ordered by slot. Each slot is written once: slots already in the
+// file, e.g. processed after the last checkpoint of an interrupted run, are skipped on resume.
+type JSONLWriter struct {
+	file    *os.File
+	enc     *json.Encoder
+	written map[uint64]bool
+	mu      sync.Mutex
+}
+
+// NewJSONLWriter opens path for appending, creating it if needed. A last line left incomplete
+// by an interrupted run is cut off.
+func NewJSONLWriter(path string) (*JSONLWriter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "open output file")
+	}
+
+	written, end, err := scanJSONL(file)
+	if err == nil {
+		err = file.Truncate(end)
+	}
+
+	if err == nil {
+		_, err = file.Seek(end, io.SeekStart)
+	}
+
+	if err != nil {
+		_ = file.Close()
+		return nil, pkgerrors.Wrap(err, "resume output file")
+	}
+
+	return &JSONLWriter{file: file, enc: json.NewEncoder(file), written: written}, nil
+}
+
+// Write appends rec as a line, unless its slot is already in the file.
+func (w *JSONLWriter) Write(rec Record) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.written[rec.Slot] {
+		return nil
+	}
+
+	if err := w.enc.Encode(rec); err != nil {
+		return pkgerrors.Wrap(err, "encode record")
+	}
+
+	w.written[rec.Slot] = true
+
+	return nil
+}
+
+// Close closes the output file.
+func (w *JSONLWriter) Close() error {
+	return pkgerrors.Wrap(w.file.Close(), "close output file")
+}
+
+// scanJSONL returns the slots of the complete records in r and the offset the last one ends at.
+func scanJSONL(r io.Reader) (map[uint64]bool, int64, error) {
+	written := make(map[uint64]bool)
+	reader := bufio.NewReader(r)
+
+	var end int64
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// Anything after the last newline was cut short.
+			return written, end, nil
+		}
+
+		if err != nil {
+			return nil, 0, pkgerrors.Wrap(err, "read output file")
+		}
+
+		var rec struct {
+			Slot uint64 `json:"slot"`
+		}
+
+		if err = json.Unmarshal(bytes.TrimSpace(line), &rec); err != nil {
+			return nil, 0, pkgerrors.Wrapf(err, "parse output line at offset %d", end)
+		}
+
+		written[rec.Slot] = true
+		end += int64(len(line))
+	}
+}
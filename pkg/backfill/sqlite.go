@@ -0,0 +1,82 @@
+package backfill
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	pkgerrors "github.com/pkg/errors"
+	// Registers the pure Go "sqlite" driver, so the binary still builds without cgo.
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchema holds one row per slot. Rewards and duties are kept as JSON, the same encoding
+// as the JSONL output, and can be queried with the SQLite JSON functions.
+const sqliteSchema = `CREATE TABLE IF NOT EXISTS records (
+	slot         INTEGER PRIMARY KEY,
+	missed       BOOLEAN NOT NULL,
+	block_reward TEXT,
+	sync_duties  TEXT
+)`
+
+// SQLiteWriter stores records in the records table of a SQLite database, one row per slot.
+// A slot written again, e.g. when a run is resumed, replaces its previous row.
+type SQLiteWriter struct {
+	db *sql.DB
+}
+
+// NewSQLiteWriter opens the database at path, creating it and its records table if needed.
+func NewSQLiteWriter(path string) (*SQLiteWriter, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "open output database")
+	}
+
+	// SQLite has a single writer; concurrent workers queue on the one connection.
+	db.SetMaxOpenConns(1)
+
+	if _, err = db.Exec(sqliteSchema); err != nil {
+		_ = db.Close()
+		return nil, pkgerrors.Wrap(err, "create records table")
+	}
+
+	return &SQLiteWriter{db: db}, nil
+}
+
+// Write stores rec, replacing any previous row for its slot.
+func (w *SQLiteWriter) Write(rec Record) error {
+	blockReward, err := jsonColumn(rec.BlockReward)
+	if err != nil {
+		return err
+	}
+
+	syncDuties, err := jsonColumn(rec.SyncDuties)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.db.Exec(
+		`INSERT OR REPLACE INTO records (slot, missed, block_reward, sync_duties) VALUES (?, ?, ?, ?)`,
+		rec.Slot, rec.Missed, blockReward, syncDuties,
+	)
+
+	return pkgerrors.Wrapf(err, "insert record of slot %d", rec.Slot)
+}
+
+// Close closes the output database.
+func (w *SQLiteWriter) Close() error {
+	return pkgerrors.Wrap(w.db.Close(), "close output database")
+}
+
+// jsonColumn encodes v as a JSON column value, NULL when v is nil.
+func jsonColumn[T any](v *T) (sql.NullString, error) {
+	if v == nil {
+		return sql.NullString{}, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return sql.NullString{}, pkgerrors.Wrap(err, "encode record")
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
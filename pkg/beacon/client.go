@@ -0,0 +1,141 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/logging"
+	"github.com/powerslider/ethereum-validator-api/pkg/metrics"
+	"github.com/powerslider/ethereum-validator-api/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = tracing.Tracer("github.com/powerslider/ethereum-validator-api/pkg/beacon")
+
+// get performs an idempotent GET of path against the beacon nodes in order of
+// preference. Connection errors and 5xx responses mark the node as failed and
+// the request is retried on the next node. It returns the response body and
+// status code of the first node that answered, or of the last one tried.
+// op names the calling method in the upstream metrics.
+func (s *Service) get(ctx context.Context, op, path string) ([]byte, int, error) {
+	return s.send(ctx, op, http.MethodGet, path, nil)
+}
+
+// post sends payload as JSON to path like get. It is only used for read-only
+// endpoints taking their arguments in the body, which are safe to retry.
+func (s *Service) post(ctx context.Context, op, path string, payload any) ([]byte, int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, 0, pkgerrors.Wrap(err, "encode beacon request")
+	}
+
+	return s.send(ctx, op, http.MethodPost, path, body)
+}
+
+// send performs a request against the beacon nodes with failover and records its outcome
+// in the upstream metrics and in a span, under which every node attempt is traced.
+// It waits for a slot first when the requests in flight are capped.
+func (s *Service) send(ctx context.Context, op, method, path string, payload []byte) ([]byte, int, error) {
+	ctx, span := tracer.Start(ctx, "beacon."+op)
+
+	if s.InFlight != nil {
+		if err := s.InFlight.Acquire(ctx, 1); err != nil {
+			err = pkgerrors.Wrap(err, "wait for beacon request slot")
+			tracing.End(span, err)
+
+			return nil, 0, err
+		}
+		defer s.InFlight.Release(1)
+	}
+
+	start := time.Now()
+
+	body, statusCode, err := s.failover(ctx, method, path, payload)
+
+	outcome := metrics.StatusOutcome(statusCode)
+	if err != nil {
+		outcome = metrics.ErrorOutcome(err)
+	}
+
+	metrics.ObserveUpstream(metrics.UpstreamBeacon, op, outcome, start)
+	span.SetAttributes(attribute.String("outcome", outcome))
+	tracing.End(span, err)
+
+	return body, statusCode, err
+}
+
+// failover performs a request against the beacon nodes in order of preference.
+func (s *Service) failover(ctx context.Context, method, path string, payload []byte) ([]byte, int, error) {
+	var (
+		lastBody   []byte
+		lastStatus int
+		lastErr    error = ErrNoBeaconNodes
+	)
+
+	for _, n := range s.orderedNodes() {
+		body, statusCode, err := s.sendTo(ctx, n.url, method, path, payload)
+		if err == nil && statusCode < http.StatusInternalServerError {
+			return body, statusCode, nil
+		}
+
+		// The caller gave up, there is no point in trying other nodes.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, 0, pkgerrors.Wrap(ctxErr, "beacon request canceled")
+		}
+
+		if err != nil {
+			lastErr = err
+		} else {
+			lastBody, lastStatus = body, statusCode
+			err = ErrUnexpectedStatusCode(statusCode)
+		}
+
+		// Logged with the request-scoped logger, so node failures are correlated with the API request.
+		logging.FromContext(ctx).WarnContext(ctx, "beacon request failed",
+			"node", n.url, "method", method, "path", path, "error", err)
+		n.markFailed(pkgerrors.Wrapf(err, "%s %s", method, path))
+	}
+
+	if lastStatus != 0 {
+		return lastBody, lastStatus, nil
+	}
+
+	return nil, 0, lastErr
+}
+
+// sendTo performs a single request of path against the node at baseURL.
+func (s *Service) sendTo(ctx context.Context, baseURL, method, path string, payload []byte) ([]byte, int, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, reqBody)
+	if err != nil {
+		return nil, 0, pkgerrors.Wrap(err, "create beacon request")
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.ConsensusClient.Do(req)
+	if err != nil {
+		return nil, 0, pkgerrors.Wrap(err, "execute beacon request")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, pkgerrors.Wrap(err, "read beacon response body")
+	}
+
+	return body, resp.StatusCode, nil
+}
@@ -0,0 +1,248 @@
+package beacon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Beacon API event topics.
+const (
+	TopicHead                = "head"
+	TopicBlock               = "block"
+	TopicFinalizedCheckpoint = "finalized_checkpoint"
+	TopicChainReorg          = "chain_reorg"
+)
+
+// EventTopics are all the topics SubscribeEvents understands.
+var EventTopics = []string{TopicHead, TopicBlock, TopicFinalizedCheckpoint, TopicChainReorg}
+
+const (
+	// minReconnectDelay and maxReconnectDelay bound the backoff between event stream reconnects.
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+
+	// maxEventSize is the largest event line accepted from the stream.
+	maxEventSize = 1 << 20
+)
+
+// Event is an event received from the beacon node event stream. It is one of
+// *HeadEvent, *BlockEvent, *FinalizedCheckpointEvent or *ChainReorgEvent.
+type Event interface {
+	Topic() string
+}
+
+// HeadEvent is emitted when the chain head changes.
+type HeadEvent struct {
+	Block                     string `json:"block"`
+	State                     string `json:"state"`
+	PreviousDutyDependentRoot string `json:"previous_duty_dependent_root"`
+	CurrentDutyDependentRoot  string `json:"current_duty_dependent_root"`
+	Slot                      uint64 `json:"slot,string"`
+	EpochTransition           bool   `json:"epoch_transition"`
+	ExecutionOptimistic       bool   `json:"execution_optimistic"`
+}
+
+// BlockEvent is emitted when a block is imported, whether or not it becomes the head.
+type BlockEvent struct {
+	Block               string `json:"block"`
+	Slot                uint64 `json:"slot,string"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+// FinalizedCheckpointEvent is emitted when a new checkpoint is finalized.
+type FinalizedCheckpointEvent struct {
+	Block               string `json:"block"`
+	State               string `json:"state"`
+	Epoch               uint64 `json:"epoch,string"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+// ChainReorgEvent is emitted when the chain head is replaced by a block that does not descend from it.
+type ChainReorgEvent struct {
+	OldHeadBlock        string `json:"old_head_block"`
+	NewHeadBlock        string `json:"new_head_block"`
+	OldHeadState        string `json:"old_head_state"`
+	NewHeadState        string `json:"new_head_state"`
+	Slot                uint64 `json:"slot,string"`
+	Depth               uint64 `json:"depth,string"`
+	Epoch               uint64 `json:"epoch,string"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+// Topic implements Event.
+func (*HeadEvent) Topic() string { return TopicHead }
+
+// Topic implements Event.
+func (*BlockEvent) Topic() string { return TopicBlock }
+
+// Topic implements Event.
+func (*FinalizedCheckpointEvent) Topic() string { return TopicFinalizedCheckpoint }
+
+// Topic implements Event.
+func (*ChainReorgEvent) Topic() string { return TopicChainReorg }
+
+// SubscribeEvents streams the given topics from /eth/v1/events of the preferred beacon node.
+// When the stream breaks, the node is marked as failed and the subscription reconnects, possibly
+// to another node, with exponential backoff. Events missed while disconnected are not replayed.
+// The returned channel is closed when ctx is canceled.
+func (s *Service) SubscribeEvents(ctx context.Context, topics ...string) <-chan Event {
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+
+		delay := minReconnectDelay
+
+		for {
+			received, err := s.streamEvents(ctx, topics, events)
+			if ctx.Err() != nil {
+				return
+			}
+
+			// A stream that delivered events was healthy; start the backoff over.
+			if received {
+				delay = minReconnectDelay
+			}
+
+			log.Printf("[Events] %v, reconnecting in %s", err, delay)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+
+			delay = min(2*delay, maxReconnectDelay)
+		}
+	}()
+
+	return events
+}
+
+// streamEvents reads events from the preferred node until the stream ends or ctx is canceled.
+// It reports whether any event was received.
+func (s *Service) streamEvents(ctx context.Context, topics []string, events chan<- Event) (bool, error) {
+	nodes := s.orderedNodes()
+	if len(nodes) == 0 {
+		return false, ErrNoBeaconNodes
+	}
+
+	n := nodes[0]
+	path := "/eth/v1/events?topics=" + url.QueryEscape(strings.Join(topics, ","))
+
+	received, err := s.readEventStream(ctx, n.url+path, events)
+	if err != nil && ctx.Err() == nil {
+		n.markFailed(pkgerrors.Wrapf(err, "GET %s", path))
+	}
+
+	return received, err
+}
+
+// readEventStream parses the Server-Sent Events served at endpoint and forwards the known events.
+func (s *Service) readEventStream(ctx context.Context, endpoint string, events chan<- Event) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, pkgerrors.Wrap(err, "create event stream request")
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+
+	// The stream stays open indefinitely, so the client timeout must not apply.
+	client := *s.ConsensusClient
+	client.Timeout = 0
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, pkgerrors.Wrap(err, "open event stream")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, pkgerrors.Wrap(ErrUnexpectedStatusCode(resp.StatusCode), "open event stream")
+	}
+
+	var (
+		received bool
+		topic    string
+		data     strings.Builder
+	)
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			// A blank line dispatches the event.
+			event, err := decodeEvent(topic, data.String())
+			topic = ""
+			data.Reset()
+
+			if err != nil {
+				log.Printf("[Events] %v", err)
+				continue
+			}
+
+			if event == nil {
+				continue
+			}
+
+			select {
+			case events <- event:
+				received = true
+			case <-ctx.Done():
+				return received, nil
+			}
+		case strings.HasPrefix(line, ":"):
+			// Comment, typically a keep-alive.
+		case strings.HasPrefix(line, "event:"):
+			topic = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return received, pkgerrors.Wrap(err, "read event stream")
+	}
+
+	return received, pkgerrors.New("event stream closed by beacon node")
+}
+
+// decodeEvent decodes the data of an event. Unknown topics decode to nil.
+func decodeEvent(topic, data string) (Event, error) {
+	var event Event
+
+	switch topic {
+	case TopicHead:
+		event = &HeadEvent{}
+	case TopicBlock:
+		event = &BlockEvent{}
+	case TopicFinalizedCheckpoint:
+		event = &FinalizedCheckpointEvent{}
+	case TopicChainReorg:
+		event = &ChainReorgEvent{}
+	default:
+		return nil, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), event); err != nil {
+		return nil, pkgerrors.Wrapf(err, "parse %s event", topic)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,97 @@
+package beacon
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// BlockID identifies a beacon block as accepted by the Beacon API: a named block
+// ("head", "genesis", "finalized", "justified"), a slot number or a 0x-prefixed block root.
+type BlockID string
+
+// StateID identifies a beacon state as accepted by the Beacon API: a named state
+// ("head", "genesis", "finalized", "justified"), a slot number or a 0x-prefixed state root.
+type StateID string
+
+const (
+	BlockHead      BlockID = "head"
+	BlockGenesis   BlockID = "genesis"
+	BlockFinalized BlockID = "finalized"
+	BlockJustified BlockID = "justified"
+)
+
+// ValidatorID identifies a validator as accepted by the Beacon API: a validator index
+// or a 0x-prefixed BLS public key.
+type ValidatorID string
+
+// FarFutureEpoch is the epoch of validator lifecycle events that are not scheduled yet.
+const FarFutureEpoch = ^uint64(0)
+
+var (
+	rootPattern   = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+	pubkeyPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{96}$`)
+)
+
+// ParseBlockID validates a block identifier. Returns ErrInvalidBlockID for anything
+// that is not a named block, a slot number or a 0x-prefixed 32-byte root.
+func ParseBlockID(s string) (BlockID, error) {
+	switch id := BlockID(strings.ToLower(s)); id {
+	case BlockHead, BlockGenesis, BlockFinalized, BlockJustified:
+		return id, nil
+	}
+
+	if _, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return BlockID(s), nil
+	}
+
+	if rootPattern.MatchString(s) {
+		return BlockID(strings.ToLower(s)), nil
+	}
+
+	return "", pkgerrors.Wrapf(ErrInvalidBlockID, "%q", s)
+}
+
+// ParseStateID validates a state identifier, which follows the same syntax as block
+// identifiers. Returns ErrInvalidBlockID when it is invalid.
+func ParseStateID(s string) (StateID, error) {
+	id, err := ParseBlockID(s)
+	return StateID(id), err
+}
+
+// ParseValidatorID validates a validator identifier. Returns ErrInvalidValidatorID for anything
+// that is not a validator index or a 0x-prefixed 48-byte public key.
+func ParseValidatorID(s string) (ValidatorID, error) {
+	if _, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return ValidatorID(s), nil
+	}
+
+	if pubkeyPattern.MatchString(s) {
+		return ValidatorID(strings.ToLower(s)), nil
+	}
+
+	return "", pkgerrors.Wrapf(ErrInvalidValidatorID, "%q", s)
+}
+
+// SlotBlockID returns the identifier of the block at slot.
+func SlotBlockID(slot uint64) BlockID {
+	return BlockID(strconv.FormatUint(slot, 10))
+}
+
+// Slot returns the slot number when the identifier is a slot.
+func (id BlockID) Slot() (uint64, bool) {
+	slot, err := strconv.ParseUint(string(id), 10, 64)
+	return slot, err == nil
+}
+
+// IsRoot reports whether the identifier is a block root.
+func (id BlockID) IsRoot() bool {
+	return strings.HasPrefix(string(id), "0x")
+}
+
+// SlotStateID returns the identifier of the state at slot.
+func SlotStateID(slot uint64) StateID {
+	return StateID(strconv.FormatUint(slot, 10))
+}
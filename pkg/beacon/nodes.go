@@ -0,0 +1,233 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// NodeStatus describes the last known state of a beacon node. URL and LastError are redacted
+// to the scheme and host of the node, as providers embed API keys in node URLs.
+type NodeStatus struct {
+	LastChecked  time.Time `json:"last_checked"`
+	URL          string    `json:"url"`
+	LastError    string    `json:"last_error,omitempty"`
+	HeadSlot     uint64    `json:"head_slot"`
+	SyncDistance uint64    `json:"sync_distance"`
+	// Active marks the node requests are currently routed to first.
+	Active       bool `json:"active"`
+	Healthy      bool `json:"healthy"`
+	IsSyncing    bool `json:"is_syncing"`
+	IsOptimistic bool `json:"is_optimistic"`
+	ELOffline    bool `json:"el_offline"`
+}
+
+// node is a single upstream beacon node together with its last known status.
+type node struct {
+	status NodeStatus
+	url    string
+	mu     sync.RWMutex
+}
+
+// rank orders nodes by preference: healthy and synced first, then healthy but
+// syncing or optimistic, then unreachable ones. Lower is better.
+func (n *node) rank() int {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	switch {
+	case !n.status.Healthy:
+		return 2
+	case n.status.IsSyncing || n.status.IsOptimistic || n.status.ELOffline:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (n *node) syncDistance() uint64 {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	return n.status.SyncDistance
+}
+
+// markFailed records a failed request so the node is deprioritized until the next health check.
+func (n *node) markFailed(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.status.Healthy = false
+	n.status.LastError = redactError(err, n.url)
+}
+
+// RedactURL returns the scheme and host of rawURL, dropping the userinfo, path and query
+// hosted providers put API keys in.
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "redacted"
+	}
+
+	return u.Scheme + "://" + u.Host
+}
+
+// redactError returns the message of err with the node URL and the request URLs it
+// mentions redacted.
+func redactError(err error, nodeURL string) string {
+	msg := err.Error()
+
+	// The HTTP client reports the full request URL, with any password masked.
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		msg = strings.ReplaceAll(msg, urlErr.URL, RedactURL(urlErr.URL))
+	}
+
+	return strings.ReplaceAll(msg, nodeURL, RedactURL(nodeURL))
+}
+
+// orderedNodes returns the configured nodes from most to least preferred.
+// Nodes with equal rank keep their configured order.
+func (s *Service) orderedNodes() []*node {
+	ordered := append([]*node(nil), s.nodes...)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ri, rj := ordered[i].rank(), ordered[j].rank()
+		if ri != rj {
+			return ri < rj
+		}
+
+		return ordered[i].syncDistance() < ordered[j].syncDistance()
+	})
+
+	return ordered
+}
+
+// NodeStatuses returns the status of every configured beacon node, most preferred first.
+func (s *Service) NodeStatuses() []NodeStatus {
+	ordered := s.orderedNodes()
+	statuses := make([]NodeStatus, 0, len(ordered))
+
+	for i, n := range ordered {
+		n.mu.RLock()
+		status := n.status
+		n.mu.RUnlock()
+
+		status.Active = i == 0
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+// RunHealthChecks probes all beacon nodes every interval until ctx is canceled.
+func (s *Service) RunHealthChecks(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.CheckNodes(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckNodes probes /eth/v1/node/health and /eth/v1/node/syncing on every
+// beacon node concurrently and updates their status.
+func (s *Service) CheckNodes(ctx context.Context) {
+	var g errgroup.Group
+
+	for _, n := range s.nodes {
+		g.Go(func() error {
+			status := s.probeNode(ctx, n.url)
+
+			n.mu.Lock()
+			n.status = status
+			n.mu.Unlock()
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+}
+
+// probeNode queries the health and sync status of a single beacon node.
+func (s *Service) probeNode(ctx context.Context, url string) NodeStatus {
+	status := NodeStatus{
+		URL:         RedactURL(url),
+		LastChecked: time.Now().UTC(),
+	}
+
+	healthCode, _, err := s.probe(ctx, url+"/eth/v1/node/health")
+	if err != nil {
+		status.LastError = redactError(pkgerrors.Wrap(err, "probe node health"), url)
+		return status
+	}
+
+	// 200: ready, 206: syncing but able to serve incomplete data, 503: not initialized or having issues.
+	if healthCode != http.StatusOK && healthCode != http.StatusPartialContent {
+		status.LastError = redactError(pkgerrors.Wrap(ErrUnexpectedStatusCode(healthCode), "probe node health"), url)
+		return status
+	}
+
+	syncCode, body, err := s.probe(ctx, url+"/eth/v1/node/syncing")
+	if err == nil && syncCode != http.StatusOK {
+		err = ErrUnexpectedStatusCode(syncCode)
+	}
+
+	if err != nil {
+		status.LastError = redactError(pkgerrors.Wrap(err, "probe node syncing"), url)
+		return status
+	}
+
+	var parsed SyncingResponse
+	if err = json.Unmarshal(body, &parsed); err != nil {
+		status.LastError = redactError(pkgerrors.Wrap(err, "parse node syncing response"), url)
+		return status
+	}
+
+	status.Healthy = true
+	status.HeadSlot = parsed.Data.HeadSlot
+	status.SyncDistance = parsed.Data.SyncDistance
+	status.IsSyncing = parsed.Data.IsSyncing || healthCode == http.StatusPartialContent
+	status.IsOptimistic = parsed.Data.IsOptimistic
+	status.ELOffline = parsed.Data.ELOffline
+
+	return status
+}
+
+// probe performs a single GET against one node, without failover.
+func (s *Service) probe(ctx context.Context, url string) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, nil, pkgerrors.Wrap(err, "create probe request")
+	}
+
+	resp, err := s.ConsensusClient.Do(req)
+	if err != nil {
+		return 0, nil, pkgerrors.Wrap(err, "execute probe request")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, pkgerrors.Wrap(err, "read probe response body")
+	}
+
+	return resp.StatusCode, body, nil
+}
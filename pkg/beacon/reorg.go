@@ -0,0 +1,72 @@
+package beacon
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// ReorgHook is called after a reorg with the first slot whose block may have changed.
+// Anything computed for that slot or a later one must be considered stale.
+type ReorgHook func(fromSlot uint64)
+
+// ReorgDetector consumes chain_reorg events and notifies the registered hooks, so that
+// memoized per-slot results are invalidated. It is safe for concurrent use.
+type ReorgDetector struct {
+	hooks []ReorgHook
+	mu    sync.RWMutex
+}
+
+// NewReorgDetector creates a new reorg detector without hooks.
+func NewReorgDetector() *ReorgDetector {
+	return &ReorgDetector{}
+}
+
+// OnReorg registers a hook called on every reorg.
+func (d *ReorgDetector) OnReorg(hook ReorgHook) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.hooks = append(d.hooks, hook)
+}
+
+// Run handles the reorgs among events until the channel is closed or ctx is canceled.
+// Other events are ignored, so events is typically SubscribeEvents(ctx, TopicChainReorg).
+func (d *ReorgDetector) Run(ctx context.Context, events <-chan Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			d.HandleEvent(event)
+		}
+	}
+}
+
+// HandleEvent handles event if it is a reorg and ignores it otherwise.
+func (d *ReorgDetector) HandleEvent(event Event) {
+	if reorg, ok := event.(*ChainReorgEvent); ok {
+		d.HandleReorg(reorg)
+	}
+}
+
+// HandleReorg notifies the hooks of a reorg. The blocks after the common ancestor of the
+// old and new head, Depth slots before the new head, may have changed.
+func (d *ReorgDetector) HandleReorg(event *ChainReorgEvent) {
+	depth := min(max(event.Depth, 1), event.Slot)
+	from := event.Slot - depth + 1
+
+	log.Printf("[Reorg] Head %s replaced by %s at slot %d (depth %d), invalidating slots from %d",
+		event.OldHeadBlock, event.NewHeadBlock, event.Slot, event.Depth, from)
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, hook := range d.hooks {
+		hook(from)
+	}
+}
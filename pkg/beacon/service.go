@@ -5,12 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/ethereum/go-ethereum/common"
 	pkgerrors "github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
-	"io"
+	"golang.org/x/sync/semaphore"
 	"net/http"
 	"strings"
-	"sync"
 )
 
 var (
@@ -18,84 +18,130 @@ var (
 	ErrSlotInFuture             = errors.New("slot is in the future")
 	ErrDutiesNotFound           = errors.New("sync duties not found for given slot")
 	ErrSlotWasMissed            = errors.New("slot was missed")
+	ErrNoExecutionPayload       = errors.New("slot has no execution payload")
+	ErrNoBeaconNodes            = errors.New("no beacon nodes configured")
+	ErrValidatorNotFound        = errors.New("validator not found")
+	ErrStateNotFound            = errors.New("state not found")
+	ErrInvalidBlockID           = errors.New("invalid block identifier")
+	ErrBlockNotCanonical        = errors.New("block is not canonical")
+	ErrInvalidValidatorID       = errors.New("invalid validator identifier")
 )
 
 // Service provides a way to interact with the consensus layer.
+// Requests are routed to the healthiest of the configured beacon nodes and
+// retried on the next one on connection errors or 5xx responses.
 type Service struct {
 	ConsensusClient *http.Client
-	ConsensusURL    string
+	// InFlight is optional; when set, it caps the requests in flight to the beacon nodes across
+	// all callers. Requests over the cap wait for one to finish.
+	InFlight *semaphore.Weighted
+	nodes    []*node
 }
 
-// NewService creates a new beacon service instance for interacting with the consensus layer.
-func NewService(consensusURL string) *Service {
+// NewService creates a new beacon service instance for interacting with the consensus layer
+// through one or more beacon nodes. When client is nil, http.DefaultClient is used.
+func NewService(consensusURLs []string, client *http.Client) *Service {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	nodes := make([]*node, 0, len(consensusURLs))
+	for _, url := range consensusURLs {
+		url = strings.TrimRight(url, "/")
+
+		// Nodes are assumed healthy until the first health check says otherwise.
+		nodes = append(nodes, &node{
+			url:    url,
+			status: NodeStatus{URL: RedactURL(url), Healthy: true},
+		})
+	}
+
 	return &Service{
-		ConsensusClient: http.DefaultClient,
-		ConsensusURL:    consensusURL,
+		ConsensusClient: client,
+		nodes:           nodes,
 	}
 }
 
-// GetBeaconHeader retrieves the beacon block header for a specific slot.
+// GetBeaconHeader retrieves the beacon block header for a block identifier.
 // Returns ErrSlotInFuture or ErrSlotMissedOrDoesNotExist when appropriate.
-func (s *Service) GetBeaconHeader(ctx context.Context, slot uint64) (*BlockHeaderResponse, error) {
-	url := fmt.Sprintf("%s/eth/v1/beacon/headers/%d", s.ConsensusURL, slot)
+func (s *Service) GetBeaconHeader(ctx context.Context, id BlockID) (*BlockHeaderResponse, error) {
+	// The Beacon API only knows "justified" as a state identifier, so resolve it
+	// to the current justified checkpoint root first.
+	if id == BlockJustified {
+		checkpoints, err := s.GetFinalityCheckpoints(ctx, StateID(BlockHead))
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "resolve justified block")
+		}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		id = BlockID(checkpoints.Data.CurrentJustified.Root)
+	}
 
+	body, statusCode, err := s.get(ctx, "GetBeaconHeader", "/eth/v1/beacon/headers/"+string(id))
 	if err != nil {
-		return nil, pkgerrors.Wrap(err, "create beacon header request")
+		return nil, pkgerrors.Wrap(err, "fetch beacon header")
 	}
 
-	resp, err := s.ConsensusClient.Do(req)
+	if statusCode != http.StatusOK {
+		return nil, handleBeaconAPIError(body, statusCode)
+	}
 
-	if err != nil {
-		return nil, pkgerrors.Wrap(err, "fetch beacon header")
+	var out BlockHeaderResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, pkgerrors.Wrap(err, "parse beacon header response")
 	}
 
-	defer resp.Body.Close()
+	return &out, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+// GetBeaconBlock retrieves the full signed beacon block for a block identifier
+// (slot, block root, "head", etc.).
+// Returns ErrSlotMissedOrDoesNotExist when the block cannot be found.
+func (s *Service) GetBeaconBlock(ctx context.Context, blockID string) (*BeaconBlockResponse, error) {
+	body, statusCode, err := s.get(ctx, "GetBeaconBlock", "/eth/v2/beacon/blocks/"+blockID)
 	if err != nil {
-		return nil, pkgerrors.Wrap(err, "read beacon header response body")
+		return nil, pkgerrors.Wrap(err, "fetch beacon block")
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, handleBeaconAPIError(body, resp.StatusCode)
+	if statusCode != http.StatusOK {
+		return nil, handleBeaconAPIError(body, statusCode)
 	}
 
-	var out BlockHeaderResponse
+	var out BeaconBlockResponse
 	if err := json.Unmarshal(body, &out); err != nil {
-		return nil, pkgerrors.Wrap(err, "parse beacon header response")
+		return nil, pkgerrors.Wrap(err, "parse beacon block response")
 	}
 
 	return &out, nil
 }
 
-// GetBlockRewardFromConsensus retrieves the block reward breakdown (in Gwei)
-// from the consensus layer using the block root.
-// Returns ErrSlotInFuture or ErrSlotMissedOrDoesNotExist when applicable.
-func (s *Service) GetBlockRewardFromConsensus(ctx context.Context, blockRoot string) (*RewardResponse, error) {
-	url := fmt.Sprintf("%s/eth/v1/beacon/rewards/blocks/%s", s.ConsensusURL, blockRoot)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// GetExecutionPayload retrieves the execution payload embedded in the beacon block
+// identified by blockID. Returns ErrNoExecutionPayload for pre-Merge blocks, whose
+// payload is either absent or zeroed out.
+func (s *Service) GetExecutionPayload(ctx context.Context, blockID string) (*ExecutionPayload, error) {
+	block, err := s.GetBeaconBlock(ctx, blockID)
 	if err != nil {
-		return nil, pkgerrors.Wrap(err, "create block reward request")
+		return nil, err
 	}
 
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := s.ConsensusClient.Do(req)
-	if err != nil {
-		return nil, pkgerrors.Wrap(err, "fetch block reward")
+	payload := block.Data.Message.Body.ExecutionPayload
+	if payload == nil || common.HexToHash(payload.BlockHash) == (common.Hash{}) {
+		return nil, pkgerrors.Wrapf(ErrNoExecutionPayload, "%s block %s", block.Version, blockID)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	return payload, nil
+}
+
+// GetBlockRewardFromConsensus retrieves the block reward breakdown (in Gwei)
+// from the consensus layer using the block root.
+// Returns ErrSlotInFuture or ErrSlotMissedOrDoesNotExist when applicable.
+func (s *Service) GetBlockRewardFromConsensus(ctx context.Context, blockRoot string) (*RewardResponse, error) {
+	body, statusCode, err := s.get(ctx, "GetBlockRewardFromConsensus", "/eth/v1/beacon/rewards/blocks/"+blockRoot)
 	if err != nil {
-		return nil, pkgerrors.Wrap(err, "read block reward response body")
+		return nil, pkgerrors.Wrap(err, "fetch block reward")
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, handleBeaconAPIError(body, resp.StatusCode)
+	if statusCode != http.StatusOK {
+		return nil, handleBeaconAPIError(body, statusCode)
 	}
 
 	var out RewardResponse
@@ -108,30 +154,17 @@ func (s *Service) GetBlockRewardFromConsensus(ctx context.Context, blockRoot str
 
 // GetCurrentSlot fetches the current head slot from the consensus layer.
 func (s *Service) GetCurrentSlot(ctx context.Context) (uint64, error) {
-	url := s.ConsensusURL + "/eth/v1/beacon/headers/head"
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return 0, pkgerrors.Wrap(err, "create head request")
-	}
-
-	resp, err := s.ConsensusClient.Do(req)
+	body, statusCode, err := s.get(ctx, "GetCurrentSlot", "/eth/v1/beacon/headers/head")
 	if err != nil {
 		return 0, pkgerrors.Wrap(err, "fetch head header")
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	if statusCode != http.StatusOK {
 		return 0, pkgerrors.Wrap(
-			ErrUnexpectedStatusCode(resp.StatusCode),
+			ErrUnexpectedStatusCode(statusCode),
 			"fetch head header")
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return 0, pkgerrors.Wrap(err, "read head response body")
-	}
-
 	var parsed HeaderResponse
 	if err := json.Unmarshal(body, &parsed); err != nil {
 		return 0, pkgerrors.Wrap(err, "parse head response")
@@ -140,34 +173,78 @@ func (s *Service) GetCurrentSlot(ctx context.Context) (uint64, error) {
 	return parsed.Data.Header.Message.Slot, nil
 }
 
-// FetchSyncCommitteeIndexes retrieves the list of validator indices
-// assigned to sync committee duties for a given slot.
-func (s *Service) FetchSyncCommitteeIndexes(ctx context.Context, slot uint64) ([]string, error) {
-	url := fmt.Sprintf("%s/eth/v1/beacon/states/%d/sync_committees", s.ConsensusURL, slot)
+// GetGenesis retrieves the genesis time and fork version of the chain.
+func (s *Service) GetGenesis(ctx context.Context) (*GenesisResponse, error) {
+	body, statusCode, err := s.get(ctx, "GetGenesis", "/eth/v1/beacon/genesis")
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch genesis")
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, handleBeaconAPIError(body, statusCode)
+	}
+
+	var out GenesisResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, pkgerrors.Wrap(err, "parse genesis response")
+	}
+
+	return &out, nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// GetSpec retrieves the chain specification constants (SECONDS_PER_SLOT, SLOTS_PER_EPOCH, etc.).
+func (s *Service) GetSpec(ctx context.Context) (*SpecResponse, error) {
+	body, statusCode, err := s.get(ctx, "GetSpec", "/eth/v1/config/spec")
 	if err != nil {
-		return nil, pkgerrors.Wrap(err, "create sync committee request")
+		return nil, pkgerrors.Wrap(err, "fetch spec")
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, handleBeaconAPIError(body, statusCode)
+	}
+
+	var out SpecResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, pkgerrors.Wrap(err, "parse spec response")
 	}
 
-	resp, err := s.ConsensusClient.Do(req)
+	return &out, nil
+}
+
+// GetFinalityCheckpoints retrieves the justified and finalized checkpoints of a state.
+func (s *Service) GetFinalityCheckpoints(ctx context.Context, state StateID) (*FinalityCheckpointsResponse, error) {
+	body, statusCode, err := s.get(ctx, "GetFinalityCheckpoints", fmt.Sprintf("/eth/v1/beacon/states/%s/finality_checkpoints", state))
 	if err != nil {
-		return nil, pkgerrors.Wrap(err, "execute sync committee request")
+		return nil, pkgerrors.Wrap(err, "fetch finality checkpoints")
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, handleBeaconAPIError(body, statusCode)
+	}
+
+	var out FinalityCheckpointsResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, pkgerrors.Wrap(err, "parse finality checkpoints response")
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	return &out, nil
+}
+
+// FetchSyncCommitteeIndexes retrieves the list of validator indices
+// assigned to sync committee duties for a given state.
+func (s *Service) FetchSyncCommitteeIndexes(ctx context.Context, state StateID) ([]string, error) {
+	body, statusCode, err := s.get(ctx, "FetchSyncCommitteeIndexes", fmt.Sprintf("/eth/v1/beacon/states/%s/sync_committees", state))
 	if err != nil {
-		return nil, pkgerrors.Wrap(err, "read sync committee response body")
+		return nil, pkgerrors.Wrap(err, "execute sync committee request")
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	if statusCode != http.StatusOK {
 		var apiError APIError
 		if err = json.Unmarshal(body, &apiError); err != nil {
 			return nil, pkgerrors.Wrap(err, "parse sync committee error")
 		}
 
-		if resp.StatusCode == http.StatusNotFound {
+		if statusCode == http.StatusNotFound {
 			return nil, pkgerrors.Wrap(ErrDutiesNotFound, apiError.Message)
 		}
 
@@ -177,7 +254,7 @@ func (s *Service) FetchSyncCommitteeIndexes(ctx context.Context, slot uint64) ([
 			return nil, pkgerrors.Wrap(ErrSlotWasMissed, apiError.Message)
 		}
 
-		return nil, pkgerrors.Wrap(ErrUnexpectedStatusCode(resp.StatusCode), apiError.Message)
+		return nil, pkgerrors.Wrap(ErrUnexpectedStatusCode(statusCode), apiError.Message)
 	}
 
 	var parsed SyncCommitteeResponse
@@ -188,37 +265,30 @@ func (s *Service) FetchSyncCommitteeIndexes(ctx context.Context, slot uint64) ([
 	return parsed.Data.Validators, nil
 }
 
-// FetchValidatorsByIDs fetches the validator public keys for a list of indices
-// at a specific slot using concurrent chunked requests.
-func (s *Service) FetchValidatorsByIDs(ctx context.Context, slot uint64, ids []string) ([]string, error) {
-	var (
-		result    []string
-		chunkSize = 100
-		mu        = &sync.Mutex{} // to safely append to shared slice
-	)
+// FetchValidatorsByIDs fetches the validators for a list of indices or public keys
+// at a specific state using concurrent chunked requests. The result is aligned with ids:
+// result[i] is the validator identified by ids[i], so order and duplicates are preserved.
+// Returns ErrValidatorNotFound when the beacon node does not know one of the ids.
+func (s *Service) FetchValidatorsByIDs(ctx context.Context, state StateID, ids []string) ([]ValidatorEntry, error) {
+	unique := uniqueIDs(ids)
+	chunkSize := 100
+	chunks := make([][]ValidatorEntry, (len(unique)+chunkSize-1)/chunkSize)
 
 	g, ctx := errgroup.WithContext(ctx)
 
-	for i := 0; i < len(ids); i += chunkSize {
-		start := i
-		end := i + chunkSize
-
-		if end > len(ids) {
-			end = len(ids)
-		}
-
-		chunk := ids[start:end]
-		chunk = append([]string(nil), chunk...) // safe copy
+	for i := range chunks {
+		start := i * chunkSize
+		end := min(start+chunkSize, len(unique))
+		chunk := unique[start:end]
 
 		g.Go(func() error {
-			validators, err := s.fetchValidatorChunk(ctx, slot, chunk)
+			validators, err := s.fetchValidatorChunk(ctx, state, chunk)
 			if err != nil {
 				return err
 			}
 
-			mu.Lock()
-			result = append(result, validators...)
-			mu.Unlock()
+			// Each goroutine owns its own slot in chunks, so no locking is needed.
+			chunks[i] = validators
 
 			return nil
 		})
@@ -228,41 +298,191 @@ func (s *Service) FetchValidatorsByIDs(ctx context.Context, slot uint64, ids []s
 		return nil, pkgerrors.Wrap(err, "fetch validator chunk")
 	}
 
-	return result, nil
-}
+	// Validators may be requested by index or by public key, so index both.
+	byID := make(map[string]ValidatorEntry, 2*len(unique))
 
-// fetchValidatorChunk fetches a single batch of validators for the given IDs at a slot.
-func (s *Service) fetchValidatorChunk(ctx context.Context, slot uint64, ids []string) ([]string, error) {
-	url := fmt.Sprintf("%s/eth/v1/beacon/states/%d/validators?", s.ConsensusURL, slot)
+	for _, validators := range chunks {
+		for _, v := range validators {
+			byID[v.Index] = v
+			byID[strings.ToLower(v.Validator.Pubkey)] = v
+		}
+	}
+
+	result := make([]ValidatorEntry, len(ids))
 
 	for i, id := range ids {
-		if i > 0 {
-			url += "&"
+		v, ok := byID[strings.ToLower(id)]
+		if !ok {
+			return nil, pkgerrors.Wrapf(ErrValidatorNotFound, "validator %s at state %s", id, state)
 		}
 
-		url += "id=" + id
+		result[i] = v
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	return result, nil
+}
+
+// GetValidator retrieves a validator by index or public key at a state, with its status and balance.
+// Returns ErrStateNotFound when the state is unknown and ErrValidatorNotFound when the validator
+// is unknown at that state.
+func (s *Service) GetValidator(ctx context.Context, state StateID, id ValidatorID) (*ValidatorResponse, error) {
+	body, statusCode, err := s.get(ctx, "GetValidator", fmt.Sprintf("/eth/v1/beacon/states/%s/validators/%s", state, id))
 	if err != nil {
-		return nil, pkgerrors.Wrap(err, "create validator chunk request")
+		return nil, pkgerrors.Wrap(err, "fetch validator")
+	}
+
+	if statusCode == http.StatusNotFound {
+		return nil, s.validatorNotFound(ctx, state, id)
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, handleBeaconAPIError(body, statusCode)
+	}
+
+	var out ValidatorResponse
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, pkgerrors.Wrap(err, "parse validator response")
 	}
 
-	resp, err := s.ConsensusClient.Do(req)
+	return &out, nil
+}
+
+// validatorNotFound tells an unknown state from a validator missing at a known state, which
+// beacon nodes both answer with 404, by looking up the state root.
+func (s *Service) validatorNotFound(ctx context.Context, state StateID, id ValidatorID) error {
+	_, statusCode, err := s.get(ctx, "GetStateRoot", fmt.Sprintf("/eth/v1/beacon/states/%s/root", state))
 	if err != nil {
-		return nil, pkgerrors.Wrap(err, "execute validator chunk request")
+		return pkgerrors.Wrap(err, "fetch state root")
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, pkgerrors.Wrap(
-			ErrUnexpectedStatusCode(resp.StatusCode),
-			"fetch validator chunk response")
+	if statusCode == http.StatusNotFound {
+		return pkgerrors.Wrapf(ErrStateNotFound, "state %s", state)
+	}
+
+	return pkgerrors.Wrapf(ErrValidatorNotFound, "validator %s at state %s", id, state)
+}
+
+// GetCommittees retrieves the attestation committees of every slot of an epoch, read from state.
+// The state must be within one epoch of the requested one.
+func (s *Service) GetCommittees(ctx context.Context, state StateID, epoch uint64) (*CommitteesResponse, error) {
+	body, statusCode, err := s.get(ctx, "GetCommittees", fmt.Sprintf("/eth/v1/beacon/states/%s/committees?epoch=%d", state, epoch))
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch committees")
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, handleBeaconAPIError(body, statusCode)
+	}
+
+	var out CommitteesResponse
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, pkgerrors.Wrap(err, "parse committees response")
+	}
+
+	return &out, nil
+}
+
+// GetProposerDuties retrieves the block proposer of every slot of an epoch.
+func (s *Service) GetProposerDuties(ctx context.Context, epoch uint64) (*ProposerDutiesResponse, error) {
+	body, statusCode, err := s.get(ctx, "GetProposerDuties", fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch))
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch proposer duties")
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, handleBeaconAPIError(body, statusCode)
+	}
+
+	var out ProposerDutiesResponse
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, pkgerrors.Wrap(err, "parse proposer duties response")
+	}
+
+	return &out, nil
+}
+
+// GetAttestationRewards retrieves the attestation rewards earned in an epoch by the given validators
+// (indices or public keys). They are only known once the following epoch has ended.
+// Returns ErrSlotMissedOrDoesNotExist when the node has no rewards for the epoch.
+func (s *Service) GetAttestationRewards(ctx context.Context, epoch uint64, ids []string) (*AttestationRewardsResponse, error) {
+	body, statusCode, err := s.post(ctx, "GetAttestationRewards", fmt.Sprintf("/eth/v1/beacon/rewards/attestations/%d", epoch), ids)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch attestation rewards")
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, handleBeaconAPIError(body, statusCode)
+	}
+
+	var out AttestationRewardsResponse
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, pkgerrors.Wrap(err, "parse attestation rewards response")
+	}
+
+	return &out, nil
+}
+
+// GetSyncCommitteeRewards retrieves the sync committee rewards earned in a block by the given
+// validators (indices or public keys). Validators outside the sync committee are not listed.
+// Returns ErrSlotMissedOrDoesNotExist when there is no such block.
+func (s *Service) GetSyncCommitteeRewards(
+	ctx context.Context,
+	id BlockID,
+	ids []string,
+) (*SyncCommitteeRewardsResponse, error) {
+	body, statusCode, err := s.post(ctx, "GetSyncCommitteeRewards", "/eth/v1/beacon/rewards/sync_committee/"+string(id), ids)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch sync committee rewards")
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, handleBeaconAPIError(body, statusCode)
+	}
+
+	var out SyncCommitteeRewardsResponse
+	if err = json.Unmarshal(body, &out); err != nil {
+		return nil, pkgerrors.Wrap(err, "parse sync committee rewards response")
+	}
+
+	return &out, nil
+}
+
+// uniqueIDs returns ids without duplicates, keeping the order of first occurrence.
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
+
+// fetchValidatorChunk fetches a single batch of validators for the given IDs at a state.
+func (s *Service) fetchValidatorChunk(ctx context.Context, state StateID, ids []string) ([]ValidatorEntry, error) {
+	path := fmt.Sprintf("/eth/v1/beacon/states/%s/validators?", state)
+
+	for i, id := range ids {
+		if i > 0 {
+			path += "&"
+		}
+
+		path += "id=" + id
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body, statusCode, err := s.get(ctx, "fetchValidatorChunk", path)
 	if err != nil {
-		return nil, pkgerrors.Wrap(err, "read validator response body")
+		return nil, pkgerrors.Wrap(err, "execute validator chunk request")
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, pkgerrors.Wrap(
+			ErrUnexpectedStatusCode(statusCode),
+			"fetch validator chunk response")
 	}
 
 	var parsed ValidatorListResponse
@@ -270,12 +490,7 @@ func (s *Service) fetchValidatorChunk(ctx context.Context, slot uint64, ids []st
 		return nil, pkgerrors.Wrap(err, "parse validator response")
 	}
 
-	var validators []string
-	for _, v := range parsed.Data {
-		validators = append(validators, v.Validator.Pubkey)
-	}
-
-	return validators, nil
+	return parsed.Data, nil
 }
 
 // handleBeaconAPIError parses a consensus-layer error response and returns a typed Go error
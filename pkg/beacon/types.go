@@ -1,8 +1,12 @@
 package beacon
 
-// BlockHeaderResponse is the response from /eth/v1/beacon/headers/{slot}
+import "encoding/json"
+
+// BlockHeaderResponse is the response from /eth/v1/beacon/headers/{block_id}
 type BlockHeaderResponse struct {
-	Data BlockHeaderData `json:"data"`
+	Data                BlockHeaderData `json:"data"`
+	ExecutionOptimistic bool            `json:"execution_optimistic"`
+	Finalized           bool            `json:"finalized"`
 }
 
 type BlockHeaderData struct {
@@ -17,8 +21,42 @@ type Header struct {
 }
 
 type HeaderMessage struct {
-	Slot          string `json:"slot"`
 	ProposerIndex string `json:"proposer_index"`
+	Slot          uint64 `json:"slot,string"`
+}
+
+// BeaconBlockResponse is the response from /eth/v2/beacon/blocks/{block_id}.
+type BeaconBlockResponse struct {
+	Version             string            `json:"version"`
+	Data                SignedBeaconBlock `json:"data"`
+	ExecutionOptimistic bool              `json:"execution_optimistic"`
+	Finalized           bool              `json:"finalized"`
+}
+
+type SignedBeaconBlock struct {
+	Message   BeaconBlock `json:"message"`
+	Signature string      `json:"signature"`
+}
+
+type BeaconBlock struct {
+	ProposerIndex string          `json:"proposer_index"`
+	ParentRoot    string          `json:"parent_root"`
+	StateRoot     string          `json:"state_root"`
+	Body          BeaconBlockBody `json:"body"`
+	Slot          uint64          `json:"slot,string"`
+}
+
+// BeaconBlockBody only carries the fields of the block body we care about.
+// ExecutionPayload is nil for pre-Bellatrix (phase0, altair) blocks.
+type BeaconBlockBody struct {
+	ExecutionPayload *ExecutionPayload `json:"execution_payload,omitempty"`
+}
+
+type ExecutionPayload struct {
+	BlockHash    string `json:"block_hash"`
+	FeeRecipient string `json:"fee_recipient"`
+	ExtraData    string `json:"extra_data"`
+	BlockNumber  uint64 `json:"block_number,string"`
 }
 
 // RewardResponse is the response from /eth/v1/beacon/rewards/blocks/{block_root}
@@ -63,14 +101,82 @@ type ValidatorListResponse struct {
 	Data []ValidatorEntry `json:"data"`
 }
 
+// ValidatorResponse is the response from /eth/v1/beacon/states/{state}/validators/{validator_id}.
+type ValidatorResponse struct {
+	Data                ValidatorEntry `json:"data"`
+	ExecutionOptimistic bool           `json:"execution_optimistic"`
+	Finalized           bool           `json:"finalized"`
+}
+
 type ValidatorEntry struct {
-	Index     string        `json:"index"`
+	Index string `json:"index"`
+	// Status is one of the Beacon API validator statuses, e.g. "active_ongoing" or "exited_slashed".
+	Status    string        `json:"status"`
 	Validator ValidatorInfo `json:"validator"`
+	// Balance is the current balance in Gwei.
+	Balance uint64 `json:"balance,string"`
 }
 
+// ValidatorInfo holds the validator record. Epochs not reached yet are FarFutureEpoch.
 type ValidatorInfo struct {
-	Pubkey                string `json:"pubkey"`
-	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Pubkey                     string `json:"pubkey"`
+	WithdrawalCredentials      string `json:"withdrawal_credentials"`
+	EffectiveBalance           uint64 `json:"effective_balance,string"`
+	ActivationEligibilityEpoch uint64 `json:"activation_eligibility_epoch,string"`
+	ActivationEpoch            uint64 `json:"activation_epoch,string"`
+	ExitEpoch                  uint64 `json:"exit_epoch,string"`
+	WithdrawableEpoch          uint64 `json:"withdrawable_epoch,string"`
+	Slashed                    bool   `json:"slashed"`
+}
+
+// ProposerDutiesResponse is the response from /eth/v1/validator/duties/proposer/{epoch}.
+type ProposerDutiesResponse struct {
+	DependentRoot       string         `json:"dependent_root"`
+	Data                []ProposerDuty `json:"data"`
+	ExecutionOptimistic bool           `json:"execution_optimistic"`
+}
+
+type ProposerDuty struct {
+	Pubkey         string `json:"pubkey"`
+	ValidatorIndex string `json:"validator_index"`
+	Slot           uint64 `json:"slot,string"`
+}
+
+// AttestationRewardsResponse is the response from /eth/v1/beacon/rewards/attestations/{epoch}.
+type AttestationRewardsResponse struct {
+	Data                AttestationRewardsData `json:"data"`
+	ExecutionOptimistic bool                   `json:"execution_optimistic"`
+	Finalized           bool                   `json:"finalized"`
+}
+
+// AttestationRewardsData only carries the rewards actually earned, not the ideal ones.
+type AttestationRewardsData struct {
+	TotalRewards []AttestationReward `json:"total_rewards"`
+}
+
+// AttestationReward is the attestation reward of a validator for an epoch, in Gwei.
+// Negative values are penalties. InclusionDelay only applies to phase0.
+type AttestationReward struct {
+	ValidatorIndex string `json:"validator_index"`
+	Head           int64  `json:"head,string"`
+	Target         int64  `json:"target,string"`
+	Source         int64  `json:"source,string"`
+	InclusionDelay int64  `json:"inclusion_delay,string"`
+	Inactivity     int64  `json:"inactivity,string"`
+}
+
+// SyncCommitteeRewardsResponse is the response from /eth/v1/beacon/rewards/sync_committee/{block_id}.
+type SyncCommitteeRewardsResponse struct {
+	Data                []SyncCommitteeReward `json:"data"`
+	ExecutionOptimistic bool                  `json:"execution_optimistic"`
+	Finalized           bool                  `json:"finalized"`
+}
+
+// SyncCommitteeReward is the reward of a sync committee member for a block, in Gwei.
+// It is negative when the member did not participate.
+type SyncCommitteeReward struct {
+	ValidatorIndex string `json:"validator_index"`
+	Reward         int64  `json:"reward,string"`
 }
 
 // SyncCommitteeResponse is the response from /eth/v1/beacon/states/{state}/sync_committees.
@@ -81,3 +187,63 @@ type SyncCommitteeResponse struct {
 type SyncCommitteeData struct {
 	Validators []string `json:"validators"`
 }
+
+// CommitteesResponse is the response from /eth/v1/beacon/states/{state}/committees.
+type CommitteesResponse struct {
+	Data                []Committee `json:"data"`
+	ExecutionOptimistic bool        `json:"execution_optimistic"`
+	Finalized           bool        `json:"finalized"`
+}
+
+// Committee is an attestation committee: the validators attesting at Slot under committee Index.
+type Committee struct {
+	Validators []string `json:"validators"`
+	Index      uint64   `json:"index,string"`
+	Slot       uint64   `json:"slot,string"`
+}
+
+// SyncingResponse is the response from /eth/v1/node/syncing.
+type SyncingResponse struct {
+	Data SyncingData `json:"data"`
+}
+
+type SyncingData struct {
+	HeadSlot     uint64 `json:"head_slot,string"`
+	SyncDistance uint64 `json:"sync_distance,string"`
+	IsSyncing    bool   `json:"is_syncing"`
+	IsOptimistic bool   `json:"is_optimistic"`
+	ELOffline    bool   `json:"el_offline"`
+}
+
+// FinalityCheckpointsResponse is the response from /eth/v1/beacon/states/{state_id}/finality_checkpoints.
+type FinalityCheckpointsResponse struct {
+	Data FinalityCheckpoints `json:"data"`
+}
+
+type FinalityCheckpoints struct {
+	PreviousJustified Checkpoint `json:"previous_justified"`
+	CurrentJustified  Checkpoint `json:"current_justified"`
+	Finalized         Checkpoint `json:"finalized"`
+}
+
+type Checkpoint struct {
+	Root  string `json:"root"`
+	Epoch uint64 `json:"epoch,string"`
+}
+
+// GenesisResponse is the response from /eth/v1/beacon/genesis.
+type GenesisResponse struct {
+	Data GenesisData `json:"data"`
+}
+
+type GenesisData struct {
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	GenesisForkVersion    string `json:"genesis_fork_version"`
+	GenesisTime           uint64 `json:"genesis_time,string"`
+}
+
+// SpecResponse is the response from /eth/v1/config/spec.
+// Most values are decimal or hex strings; newer forks also add lists and objects.
+type SpecResponse struct {
+	Data map[string]json.RawMessage `json:"data"`
+}
@@ -0,0 +1,142 @@
+package blockreward
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/metrics"
+	"github.com/powerslider/ethereum-validator-api/pkg/tracing"
+)
+
+// weiPerGwei is the conversion factor between Wei and Gwei.
+var weiPerGwei = big.NewInt(1_000_000_000)
+
+// ExecutionReward holds the execution-layer part of a proposer's income (all values in Wei).
+type ExecutionReward struct {
+	// PriorityFees is the sum of effective tip × gas used over all block transactions.
+	// These are paid to the block's fee recipient, which is the builder for MEV blocks.
+	PriorityFees *big.Int
+	// MEVPayment is the value of the builder-to-proposer payment transaction, if any.
+	MEVPayment *big.Int
+	// MEVPaymentTx is the hash of the builder-to-proposer payment transaction, if any.
+	MEVPaymentTx string
+	// Total is what the proposer actually earned on the execution layer:
+	// the MEV payment for builder blocks, the priority fees otherwise.
+	Total *big.Int
+}
+
+// blockByHash fetches an execution block and records the call in the upstream metrics and traces.
+func (s *Service) blockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	ctx, span := tracer.Start(ctx, "execution.BlockByHash")
+	start := time.Now()
+	block, err := s.ExecClient.BlockByHash(ctx, hash)
+	metrics.ObserveUpstream(metrics.UpstreamExecution, "BlockByHash", executionOutcome(err), start)
+	tracing.End(span, err)
+
+	return block, err //nolint:wrapcheck // wrapped by the caller
+}
+
+// blockReceipts fetches the receipts of an execution block and records the call in the upstream metrics and traces.
+func (s *Service) blockReceipts(ctx context.Context, hash common.Hash) ([]*types.Receipt, error) {
+	ctx, span := tracer.Start(ctx, "execution.BlockReceipts")
+	start := time.Now()
+	receipts, err := s.ExecClient.BlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(hash, false))
+	metrics.ObserveUpstream(metrics.UpstreamExecution, "BlockReceipts", executionOutcome(err), start)
+	tracing.End(span, err)
+
+	return receipts, err //nolint:wrapcheck // wrapped by the caller
+}
+
+// executionOutcome classifies the result of an execution client call.
+func executionOutcome(err error) string {
+	var httpErr rpc.HTTPError
+
+	switch {
+	case err == nil:
+		return metrics.OutcomeOK
+	case errors.Is(err, ethereum.NotFound):
+		return metrics.OutcomeNotFound
+	case errors.As(err, &httpErr):
+		return metrics.StatusOutcome(httpErr.StatusCode)
+	default:
+		return metrics.ErrorOutcome(err)
+	}
+}
+
+// computeExecutionReward derives the execution-layer reward from a block and its receipts.
+func computeExecutionReward(block *types.Block, receipts []*types.Receipt) (*ExecutionReward, error) {
+	txs := block.Transactions()
+	if len(txs) != len(receipts) {
+		return nil, pkgerrors.Errorf(
+			"block %s has %d transactions but %d receipts", block.Hash(), len(txs), len(receipts))
+	}
+
+	priorityFees := new(big.Int)
+
+	for i, tx := range txs {
+		tip, err := tx.EffectiveGasTip(block.BaseFee())
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "compute effective tip of tx %s", tx.Hash())
+		}
+
+		priorityFees.Add(priorityFees, new(big.Int).Mul(tip, new(big.Int).SetUint64(receipts[i].GasUsed)))
+	}
+
+	reward := &ExecutionReward{
+		PriorityFees: priorityFees,
+		MEVPayment:   new(big.Int),
+		Total:        new(big.Int).Set(priorityFees),
+	}
+
+	if paymentTx := findMEVPayment(block); paymentTx != nil {
+		reward.MEVPayment.Set(paymentTx.Value())
+		reward.MEVPaymentTx = paymentTx.Hash().Hex()
+		reward.Total.Set(paymentTx.Value())
+	}
+
+	return reward, nil
+}
+
+// findMEVPayment returns the builder-to-proposer payment transaction of an MEV block.
+// Builders append it as the last transaction of the block, sending value from the
+// block's fee recipient (the builder) to the proposer's fee recipient.
+func findMEVPayment(block *types.Block) *types.Transaction {
+	txs := block.Transactions()
+	if len(txs) == 0 {
+		return nil
+	}
+
+	last := txs[len(txs)-1]
+	if last.To() == nil || *last.To() == block.Coinbase() || last.Value().Sign() <= 0 {
+		return nil
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(last.ChainId()), last)
+	if err != nil || from != block.Coinbase() {
+		return nil
+	}
+
+	return last
+}
+
+// gweiToWei converts a decimal Gwei string to Wei.
+func gweiToWei(gwei string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(gwei, 10)
+	if !ok {
+		return nil, pkgerrors.Errorf("invalid gwei amount %q", gwei)
+	}
+
+	return v.Mul(v, weiPerGwei), nil
+}
+
+// weiToGwei converts Wei to Gwei, truncating any fractional Gwei.
+func weiToGwei(wei *big.Int) *big.Int {
+	return new(big.Int).Quo(wei, weiPerGwei)
+}
@@ -0,0 +1,166 @@
+package blockreward
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultMaxRange is the default maximum number of slots per range request (one day).
+	DefaultMaxRange = 7200
+	// DefaultConcurrency is the default number of slots fetched in parallel for range requests.
+	DefaultConcurrency = 8
+)
+
+var (
+	ErrInvalidRange  = errors.New("invalid slot range")
+	ErrRangeTooLarge = errors.New("slot range exceeds the maximum allowed")
+)
+
+// SlotResult is the outcome of a single slot within a range request.
+// Result is nil when the slot was missed or could not be computed.
+type SlotResult struct {
+	Result *Result
+	// Error describes why a non-missed slot has no result, e.g. a pre-Merge slot.
+	Error  string
+	Slot   uint64
+	Missed bool
+}
+
+// RangeTotals aggregates the rewards of all proposed blocks within a range.
+type RangeTotals struct {
+	// ConsensusGwei is the sum of consensus-layer rewards, in Gwei.
+	ConsensusGwei *big.Int
+	// ExecutionWei is the sum of execution-layer rewards, in Wei.
+	ExecutionWei *big.Int
+	TotalWei     *big.Int
+	TotalGwei    *big.Int
+	Proposed     int
+	Missed       int
+	MEV          int
+}
+
+// RangeResult holds the per-slot results of a range request, ordered by slot, and their totals.
+type RangeResult struct {
+	Totals RangeTotals
+	Slots  []SlotResult
+	From   uint64
+	To     uint64
+}
+
+// GetBlockRewards calculates the block rewards of every slot in [from, to] using bounded
+// concurrency. Missed slots and slots without an execution payload are reported inline;
+// any other error fails the whole request. Returns ErrInvalidRange or ErrRangeTooLarge
+// when the range is invalid.
+func (s *Service) GetBlockRewards(ctx context.Context, from, to uint64) (*RangeResult, error) {
+	if from > to {
+		return nil, pkgerrors.Wrapf(ErrInvalidRange, "from %d is after to %d", from, to)
+	}
+
+	maxRange := s.MaxRange
+	if maxRange == 0 {
+		maxRange = DefaultMaxRange
+	}
+
+	if to-from+1 > maxRange {
+		return nil, pkgerrors.Wrapf(ErrRangeTooLarge, "%d slots requested, at most %d allowed", to-from+1, maxRange)
+	}
+
+	// Reject the whole range up front rather than failing on its first future slot.
+	if s.Clock.IsFuture(to) {
+		return nil, pkgerrors.Wrapf(beacon.ErrSlotInFuture, "slot %d", to)
+	}
+
+	concurrency := s.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	slots := make([]SlotResult, to-from+1)
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+
+	for i := range slots {
+		slot := from + uint64(i)
+
+		g.Go(func() error {
+			result, err := s.GetBlockReward(ctx, beacon.SlotBlockID(slot))
+
+			// Each goroutine writes to its own index, which keeps slots ordered without locking.
+			slots[i] = SlotResult{Slot: slot, Result: result}
+
+			switch cause := pkgerrors.Cause(err); {
+			case err == nil:
+				return nil
+			case errors.Is(cause, beacon.ErrSlotMissedOrDoesNotExist):
+				slots[i].Missed = true
+				return nil
+			case errors.Is(cause, beacon.ErrNoExecutionPayload):
+				slots[i].Error = err.Error()
+				return nil
+			default:
+				return pkgerrors.Wrapf(err, "slot %d", slot)
+			}
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch block rewards")
+	}
+
+	return &RangeResult{
+		From:   from,
+		To:     to,
+		Slots:  slots,
+		Totals: sumRewards(slots),
+	}, nil
+}
+
+// sumRewards aggregates the rewards of the given slots.
+func sumRewards(slots []SlotResult) RangeTotals {
+	totals := RangeTotals{
+		ConsensusGwei: new(big.Int),
+		ExecutionWei:  new(big.Int),
+		TotalWei:      new(big.Int),
+	}
+
+	for _, slot := range slots {
+		if slot.Missed {
+			totals.Missed++
+			continue
+		}
+
+		result := slot.Result
+		if result == nil {
+			continue
+		}
+
+		totals.Proposed++
+
+		if result.Status == statusMEV {
+			totals.MEV++
+		}
+
+		if consensus, ok := new(big.Int).SetString(result.Reward, 10); ok {
+			totals.ConsensusGwei.Add(totals.ConsensusGwei, consensus)
+		}
+
+		if result.Execution != nil {
+			totals.ExecutionWei.Add(totals.ExecutionWei, result.Execution.Total)
+		}
+
+		if result.TotalWei != nil {
+			totals.TotalWei.Add(totals.TotalWei, result.TotalWei)
+		}
+	}
+
+	totals.TotalGwei = weiToGwei(totals.TotalWei)
+
+	return totals
+}
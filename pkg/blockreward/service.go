@@ -2,102 +2,324 @@ package blockreward
 
 import (
 	"context"
+	"errors"
 	pkgerrors "github.com/pkg/errors"
 	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/builders"
+	"github.com/powerslider/ethereum-validator-api/pkg/relay"
+	"github.com/powerslider/ethereum-validator-api/pkg/store"
+	"github.com/powerslider/ethereum-validator-api/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"math/big"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
+var tracer = tracing.Tracer("github.com/powerslider/ethereum-validator-api/pkg/blockreward")
+
 const (
 	statusVanilla = "vanilla"
 	statusMEV     = "mev"
 )
 
-var mevRelaySignatures = []string{
-	"flashbots",
-	"bloxroute",
-	"eden",
-	"manifold",
-	"builder0x69",
-	"rsync-builder",
-	"beaverbuild",
-	"aestus",
-	"titans",
-	"relayooor",
-}
-
 type BeaconService interface {
-	GetCurrentSlot(ctx context.Context) (uint64, error)
-	GetBeaconHeader(ctx context.Context, slot uint64) (*beacon.BlockHeaderResponse, error)
+	GetBeaconHeader(ctx context.Context, id beacon.BlockID) (*beacon.BlockHeaderResponse, error)
 	GetBlockRewardFromConsensus(ctx context.Context, blockRoot string) (*beacon.RewardResponse, error)
+	GetExecutionPayload(ctx context.Context, blockID string) (*beacon.ExecutionPayload, error)
+}
+
+// ExecutionClient is the subset of the execution-layer client used for reward calculation.
+// It is satisfied by *ethclient.Client.
+type ExecutionClient interface {
+	BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error)
+	BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error)
+}
+
+// Clock tells whether a slot has started yet. It is satisfied by *chaintime.Clock.
+type Clock interface {
+	IsFuture(slot uint64) bool
+}
+
+// BuilderRegistry identifies the builder of a block.
+type BuilderRegistry interface {
+	Identify(block builders.Block) (string, bool)
+}
+
+// RelayService looks up the MEV-Boost bid delivered for a slot.
+type RelayService interface {
+	GetDeliveredBid(ctx context.Context, slot uint64, blockHash string) (*relay.DeliveredBid, error)
 }
 
 // Service provides block reward calculation functionality.
 type Service struct {
-	ExecClient    *ethclient.Client
+	ExecClient    ExecutionClient
 	BeaconService BeaconService
+	Clock         Clock
+	// Builders is optional; without it blocks are only classified by their MEV payment.
+	Builders BuilderRegistry
+	// Relays is optional; when set, relay data is the authoritative MEV signal. Without it,
+	// results are classified by the builder registry and MEV payment only, and stored results
+	// classified that way are computed again once relays are set.
+	Relays RelayService
+	// Store is optional; when set, computed results are persisted and reused.
+	Store store.Backend
+	// MaxRange is the maximum number of slots per GetBlockRewards call (DefaultMaxRange when zero).
+	MaxRange uint64
+	// Concurrency bounds the slots fetched in parallel by GetBlockRewards (DefaultConcurrency when zero).
+	Concurrency int
 }
 
 // NewService creates a new block reward service instance.
-func NewService(client *ethclient.Client, svc BeaconService) *Service {
+func NewService(
+	client ExecutionClient,
+	svc BeaconService,
+	clock Clock,
+	registry BuilderRegistry,
+	relays RelayService,
+) *Service {
 	return &Service{
 		ExecClient:    client,
 		BeaconService: svc,
+		Clock:         clock,
+		Builders:      registry,
+		Relays:        relays,
+		MaxRange:      DefaultMaxRange,
+		Concurrency:   DefaultConcurrency,
 	}
 }
 
+// storeBucket is the store bucket holding computed block rewards.
+const storeBucket = "blockrewards"
+
+// results returns the stored results, disabled while Store is nil.
+func (s *Service) results() store.Bucket[Result] {
+	return store.Bucket[Result]{
+		Backend:       s.Store,
+		Name:          storeBucket,
+		MarkFinalized: func(result *Result) { result.Finalized = true },
+	}
+}
+
+// InvalidateFrom discards the stored non-finalized results of fromSlot and later slots,
+// which may have been computed for blocks reorged out. It is meant to be a beacon.ReorgHook.
+func (s *Service) InvalidateFrom(fromSlot uint64) {
+	s.results().InvalidateFrom(fromSlot)
+}
+
 type Result struct {
-	Status string
+	// Execution is the execution-layer reward breakdown (in Wei).
+	Execution *ExecutionReward
+	// TotalWei is the consensus and execution reward combined, in Wei.
+	TotalWei *big.Int
+	// TotalGwei is TotalWei expressed in Gwei (fractional Gwei truncated).
+	TotalGwei *big.Int
+	// Bid is the MEV-Boost bid delivered by relays for this block, nil for locally built blocks.
+	Bid *relay.DeliveredBid
+	// BlockRoot is the root of the block the reward was computed for.
+	BlockRoot string
+	Status    string
+	// Builder is the name of the identified block builder, empty when unknown.
+	Builder string
+	// Reward is the consensus-layer reward in Gwei.
 	Reward string
+	// Slot is the slot the block identifier resolved to.
+	Slot uint64
+	// Finalized results can no longer change. Others may, after a reorg replaces BlockRoot.
+	Finalized bool
+	// RelayChecked is true when the relays confirmed Status: one delivered the block or all of
+	// them answered that none did. Otherwise relay-delivered blocks may show as vanilla.
+	RelayChecked bool
 }
 
-// GetBlockReward calculates the block reward earned by the validator at a given slot.
-// It returns the block status ("vanilla" or "mev") and the reward amount in Gwei.
-func (s *Service) GetBlockReward(ctx context.Context, slot uint64) (*Result, error) {
-	// Step 0: Validate if slot is in the future.
-	currentSlot, err := s.BeaconService.GetCurrentSlot(ctx)
-	if err != nil {
-		return nil, pkgerrors.Wrap(err, "fetch current slot")
-	}
+// GetBlockReward calculates the block reward earned by the validator for a given block
+// (slot, block root or named block such as "head" or "finalized").
+// It returns the block status ("vanilla" or "mev"), the identified builder, the consensus-layer reward in Gwei,
+// the execution-layer reward (priority fees or MEV payment) in Wei and their total.
+// Returns beacon.ErrBlockNotCanonical for a block that was reorged out.
+func (s *Service) GetBlockReward(ctx context.Context, id beacon.BlockID) (*Result, error) {
+	ctx, span := tracer.Start(ctx, "blockreward.GetBlockReward", trace.WithAttributes(attribute.String("block_id", string(id))))
+	result, err := s.getBlockReward(ctx, id)
+	tracing.End(span, err)
+
+	return result, err
+}
 
-	if slot > currentSlot+1 {
+// getBlockReward calculates the block reward of GetBlockReward, within its span.
+func (s *Service) getBlockReward(ctx context.Context, id beacon.BlockID) (*Result, error) {
+	// Step 0: Validate if slot is in the future. Named blocks and roots always refer to the past.
+	if requested, ok := id.Slot(); ok && s.Clock.IsFuture(requested) {
 		return nil, beacon.ErrSlotInFuture
 	}
 
-	// Step 1: Get block header to retrieve proposer index and block root.
-	headerResp, err := s.BeaconService.GetBeaconHeader(ctx, slot)
+	// Finalized results never change, so they are served without going upstream.
+	if result := s.usable(s.results().LoadFinalized(id)); result != nil {
+		return result, nil
+	}
+
+	// Step 1: Get block header to retrieve proposer index, slot and block root.
+	stepCtx, span := tracer.Start(ctx, "fetch block header")
+	headerResp, err := s.BeaconService.GetBeaconHeader(stepCtx, id)
+	tracing.End(span, err)
+
 	if err != nil {
 		return nil, pkgerrors.Wrap(err, "fetch block header")
 	}
 
 	blockRoot := headerResp.Data.Root
+	slot := headerResp.Data.Header.Message.Slot
+
+	// A block looked up by root may have been reorged out; its reward was never earned.
+	if !headerResp.Data.Canonical {
+		return nil, pkgerrors.Wrapf(beacon.ErrBlockNotCanonical, "block %s at slot %d", blockRoot, slot)
+	}
+
+	block := store.Meta{Slot: slot, Root: blockRoot, Finalized: headerResp.Finalized}
+	if result := s.usable(s.results().LoadCanonical(block)); result != nil {
+		return result, nil
+	}
 
 	// Step 2: Get consensus-layer reward (already in Gwei).
-	rewardResp, err := s.BeaconService.GetBlockRewardFromConsensus(ctx, blockRoot)
+	stepCtx, span = tracer.Start(ctx, "fetch consensus-layer reward")
+	rewardResp, err := s.BeaconService.GetBlockRewardFromConsensus(stepCtx, blockRoot)
+	tracing.End(span, err)
+
 	if err != nil {
 		return nil, pkgerrors.Wrap(err, "fetch consensus-layer reward")
 	}
 
-	// Step 3: Fetch execution block to inspect ExtraData for MEV tag.
-	execBlock, err := s.ExecClient.BlockByNumber(ctx, new(big.Int).SetUint64(slot))
+	// Step 3: Resolve the execution block through the beacon block's execution payload,
+	// since slot numbers and execution block numbers are unrelated.
+	stepCtx, span = tracer.Start(ctx, "fetch execution payload")
+	payload, err := s.BeaconService.GetExecutionPayload(stepCtx, blockRoot)
+	tracing.End(span, err)
+
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch execution payload")
+	}
+
+	// Step 4: Fetch execution block and its receipts to compute priority fees
+	// and identify the builder.
+	blockHash := common.HexToHash(payload.BlockHash)
+
+	execBlock, err := s.blockByHash(ctx, blockHash)
 	if err != nil {
 		return nil, pkgerrors.Wrap(err, "fetch execution block")
 	}
 
-	extra := strings.ToLower(strings.TrimSpace(string(execBlock.Extra())))
+	receipts, err := s.blockReceipts(ctx, blockHash)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch execution block receipts")
+	}
+
+	execReward, err := computeExecutionReward(execBlock, receipts)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "compute execution reward")
+	}
+
+	consensusWei, err := gweiToWei(rewardResp.Data.Total)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "parse consensus-layer reward")
+	}
+
+	totalWei := new(big.Int).Add(consensusWei, execReward.Total)
+
+	// Step 5: Ask the relays whether they delivered this block. Relay data is authoritative,
+	// the builder registry and MEV payment detection are used as fallback.
+	bid, relayChecked, err := s.fetchDeliveredBid(ctx, slot, payload.BlockHash)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch relay bid")
+	}
+
+	builder := s.identifyBuilder(execBlock, bid)
 	status := statusVanilla
 
-	for _, sig := range mevRelaySignatures {
-		if strings.Contains(extra, sig) {
-			status = statusMEV
-			break
-		}
+	if bid != nil || execReward.MEVPaymentTx != "" || builder != "" {
+		status = statusMEV
+	}
+
+	result := &Result{
+		Slot:      slot,
+		BlockRoot: blockRoot,
+		Status:    status,
+		Builder:   builder,
+		Bid:       bid,
+		Reward:    rewardResp.Data.Total,
+		Execution: execReward,
+		TotalWei:  totalWei,
+		TotalGwei: weiToGwei(totalWei),
+		Finalized: headerResp.Finalized,
+		// Without relays the classification is as good as it gets, and is kept.
+		RelayChecked: relayChecked,
+	}
+
+	// A relay that failed may have delivered the block, so the result is only kept once all
+	// relays answered, or when there are no relays to ask.
+	if relayChecked || s.Relays == nil {
+		s.results().Save(block, result)
+	}
+
+	return result, nil
+}
+
+// usable returns a stored result, or nil when it was classified without relays that are now
+// configured and must be computed again.
+func (s *Service) usable(result *Result) *Result {
+	if result != nil && !result.RelayChecked && s.Relays != nil {
+		return nil
+	}
+
+	return result
+}
+
+// fetchDeliveredBid returns the relay bid delivered for the block, or nil when no relay
+// delivered it or relay lookup is disabled, and whether the relays confirmed that. Returns
+// relay.ErrRelaysUnavailable when no relay could be queried, since the block cannot be
+// classified without them.
+func (s *Service) fetchDeliveredBid(
+	ctx context.Context,
+	slot uint64,
+	blockHash string,
+) (*relay.DeliveredBid, bool, error) {
+	if s.Relays == nil {
+		return nil, false, nil
+	}
+
+	ctx, span := tracer.Start(ctx, "fetch relay bid")
+	bid, err := s.Relays.GetDeliveredBid(ctx, slot, blockHash)
+	checked := err == nil
+
+	switch cause := pkgerrors.Cause(err); {
+	case errors.Is(cause, relay.ErrPayloadNotDelivered):
+		err, checked = nil, true
+	case errors.Is(cause, relay.ErrRelaysIncomplete):
+		// Served with the fallback classification, but not confirmed.
+		err = nil
+	}
+
+	tracing.End(span, err)
+
+	return bid, checked, err //nolint:wrapcheck // wrapped by the caller
+}
+
+// identifyBuilder looks up the builder of an execution block in the builder registry.
+func (s *Service) identifyBuilder(block *types.Block, bid *relay.DeliveredBid) string {
+	if s.Builders == nil {
+		return ""
 	}
 
-	return &Result{
-		Status: status,
-		Reward: rewardResp.Data.Total,
-	}, nil
+	query := builders.Block{
+		ExtraData:    block.Extra(),
+		FeeRecipient: block.Coinbase(),
+	}
+
+	if bid != nil {
+		query.BuilderPubkey = bid.BuilderPubkey
+	}
+
+	name, _ := s.Builders.Identify(query)
+
+	return name
 }
@@ -0,0 +1,313 @@
+package blockreward_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/blockreward"
+	"github.com/powerslider/ethereum-validator-api/pkg/builders"
+	"github.com/powerslider/ethereum-validator-api/pkg/relay"
+	"github.com/powerslider/ethereum-validator-api/pkg/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	chainID = big.NewInt(1)
+	baseFee = big.NewInt(10_000_000_000) // 10 Gwei
+)
+
+type mockBeaconService struct {
+	blockHash string
+	reorged   bool
+}
+
+func (m *mockBeaconService) GetBeaconHeader(ctx context.Context, id beacon.BlockID) (*beacon.BlockHeaderResponse, error) {
+	resp := &beacon.BlockHeaderResponse{Data: beacon.BlockHeaderData{Root: "0xroot", Canonical: !m.reorged}}
+	resp.Data.Header.Message.Slot = 100
+
+	return resp, nil
+}
+
+func (m *mockBeaconService) GetBlockRewardFromConsensus(ctx context.Context, blockRoot string) (*beacon.RewardResponse, error) {
+	return &beacon.RewardResponse{Data: beacon.RewardData{Total: "30000000"}}, nil
+}
+
+func (m *mockBeaconService) GetExecutionPayload(ctx context.Context, blockID string) (*beacon.ExecutionPayload, error) {
+	return &beacon.ExecutionPayload{BlockHash: m.blockHash}, nil
+}
+
+type mockClock struct{}
+
+func (mockClock) IsFuture(slot uint64) bool {
+	return slot > 200
+}
+
+type mockExecClient struct {
+	block    *types.Block
+	receipts []*types.Receipt
+}
+
+func (m *mockExecClient) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return m.block, nil
+}
+
+func (m *mockExecClient) BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	return m.receipts, nil
+}
+
+type mockRelayService struct {
+	bid *relay.DeliveredBid
+	err error
+}
+
+func (m *mockRelayService) GetDeliveredBid(ctx context.Context, slot uint64, blockHash string) (*relay.DeliveredBid, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	if m.bid == nil {
+		return nil, relay.ErrPayloadNotDelivered
+	}
+
+	return m.bid, nil
+}
+
+func signTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, to common.Address, value, tip *big.Int) *types.Transaction {
+	t.Helper()
+
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: tip,
+		GasFeeCap: new(big.Int).Add(baseFee, tip),
+		Gas:       21_000,
+		To:        &to,
+		Value:     value,
+	})
+	require.NoError(t, err)
+
+	return tx
+}
+
+func newBlock(coinbase common.Address, txs ...*types.Transaction) (*types.Block, []*types.Receipt) {
+	receipts := make([]*types.Receipt, len(txs))
+	for i := range txs {
+		receipts[i] = &types.Receipt{GasUsed: 21_000}
+	}
+
+	header := &types.Header{Number: big.NewInt(100), Coinbase: coinbase, BaseFee: baseFee}
+
+	return types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: txs}), receipts
+}
+
+func TestGetBlockReward(t *testing.T) {
+	t.Parallel()
+
+	userKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	builderKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	builder := crypto.PubkeyToAddress(builderKey.PublicKey)
+	proposer := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	oneGwei := big.NewInt(1_000_000_000)
+
+	t.Run("vanilla block earns priority fees", func(t *testing.T) {
+		t.Parallel()
+
+		block, receipts := newBlock(proposer,
+			signTx(t, userKey, 0, builder, big.NewInt(1), oneGwei),
+			signTx(t, userKey, 1, builder, big.NewInt(1), new(big.Int).Mul(oneGwei, big.NewInt(2))),
+		)
+
+		svc := blockreward.NewService(
+			&mockExecClient{block: block, receipts: receipts},
+			&mockBeaconService{blockHash: block.Hash().Hex()},
+			mockClock{},
+			nil,
+			nil,
+		)
+
+		result, err := svc.GetBlockReward(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+
+		// 21000 gas × (1 + 2) Gwei tip.
+		expectedFees := big.NewInt(63_000_000_000_000)
+
+		assert.Equal(t, "vanilla", result.Status)
+		assert.Equal(t, "30000000", result.Reward)
+		assert.Equal(t, 0, expectedFees.Cmp(result.Execution.PriorityFees))
+		assert.Equal(t, 0, expectedFees.Cmp(result.Execution.Total))
+		assert.Empty(t, result.Execution.MEVPaymentTx)
+		assert.Equal(t, "30063000000000000", result.TotalWei.String())
+		assert.Equal(t, "30063000", result.TotalGwei.String())
+	})
+
+	t.Run("mev block earns builder payment", func(t *testing.T) {
+		t.Parallel()
+
+		payment := big.NewInt(50_000_000_000_000_000) // 0.05 ETH
+		paymentTx := signTx(t, builderKey, 0, proposer, payment, new(big.Int))
+
+		block, receipts := newBlock(builder,
+			signTx(t, userKey, 0, proposer, big.NewInt(1), oneGwei),
+			paymentTx,
+		)
+
+		registry, err := builders.NewRegistry([]builders.Builder{
+			{Name: "Test Builder", FeeRecipients: []string{builder.Hex()}},
+		})
+		require.NoError(t, err)
+
+		svc := blockreward.NewService(
+			&mockExecClient{block: block, receipts: receipts},
+			&mockBeaconService{blockHash: block.Hash().Hex()},
+			mockClock{},
+			registry,
+			nil,
+		)
+
+		result, err := svc.GetBlockReward(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+
+		assert.Equal(t, "mev", result.Status)
+		assert.Equal(t, "Test Builder", result.Builder)
+		assert.Equal(t, paymentTx.Hash().Hex(), result.Execution.MEVPaymentTx)
+		assert.Equal(t, 0, payment.Cmp(result.Execution.MEVPayment))
+		assert.Equal(t, 0, payment.Cmp(result.Execution.Total))
+		assert.Equal(t, "21000000000000", result.Execution.PriorityFees.String())
+		assert.Equal(t, "80000000000000000", result.TotalWei.String())
+	})
+	t.Run("relay delivered block is mev", func(t *testing.T) {
+		t.Parallel()
+
+		// No payment transaction and no registry match on fee recipient or extra data.
+		block, receipts := newBlock(proposer, signTx(t, userKey, 0, builder, big.NewInt(1), oneGwei))
+
+		registry, err := builders.NewRegistry([]builders.Builder{
+			{Name: "Relay Builder", Pubkeys: []string{"0xBuilderPubkey"}},
+		})
+		require.NoError(t, err)
+
+		bid := &relay.DeliveredBid{
+			Slot:          100,
+			BlockHash:     block.Hash().Hex(),
+			BuilderPubkey: "0xbuilderpubkey",
+			Value:         big.NewInt(7),
+			Relays:        []string{"relay.example"},
+		}
+
+		svc := blockreward.NewService(
+			&mockExecClient{block: block, receipts: receipts},
+			&mockBeaconService{blockHash: block.Hash().Hex()},
+			mockClock{},
+			registry,
+			&mockRelayService{bid: bid},
+		)
+
+		result, err := svc.GetBlockReward(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+
+		assert.Equal(t, "mev", result.Status)
+		assert.Equal(t, "Relay Builder", result.Builder)
+		assert.Equal(t, bid, result.Bid)
+	})
+	t.Run("unreachable relays fail the lookup and nothing is stored", func(t *testing.T) {
+		t.Parallel()
+
+		block, receipts := newBlock(proposer, signTx(t, userKey, 0, builder, big.NewInt(1), oneGwei))
+
+		st, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = st.Close() })
+
+		svc := blockreward.NewService(
+			&mockExecClient{block: block, receipts: receipts},
+			&mockBeaconService{blockHash: block.Hash().Hex()},
+			mockClock{},
+			nil,
+			&mockRelayService{err: pkgerrors.Wrap(relay.ErrRelaysUnavailable, "relay.example: timeout")},
+		)
+		svc.Store = st
+
+		_, err = svc.GetBlockReward(context.Background(), beacon.SlotBlockID(100))
+		require.ErrorIs(t, err, relay.ErrRelaysUnavailable)
+
+		var stored blockreward.Result
+
+		_, err = st.Get("blockrewards", 100, &stored)
+		require.ErrorIs(t, err, store.ErrNotFound)
+
+		// With only some relays answering, the block may still have been relay-delivered:
+		// the fallback classification is served, but not stored.
+		svc.Relays = &mockRelayService{err: pkgerrors.Wrap(relay.ErrRelaysIncomplete, "relay.example: timeout")}
+
+		result, err := svc.GetBlockReward(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+		assert.Equal(t, "vanilla", result.Status)
+		assert.False(t, result.RelayChecked)
+
+		_, err = st.Get("blockrewards", 100, &stored)
+		require.ErrorIs(t, err, store.ErrNotFound)
+	})
+	t.Run("results classified without relays are stored and rechecked once relays are set", func(t *testing.T) {
+		t.Parallel()
+
+		block, receipts := newBlock(proposer, signTx(t, userKey, 0, builder, big.NewInt(1), oneGwei))
+
+		st, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = st.Close() })
+
+		svc := blockreward.NewService(
+			&mockExecClient{block: block, receipts: receipts},
+			&mockBeaconService{blockHash: block.Hash().Hex()},
+			mockClock{},
+			nil,
+			nil,
+		)
+		svc.Store = st
+
+		result, err := svc.GetBlockReward(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+		assert.False(t, result.RelayChecked)
+
+		var stored blockreward.Result
+
+		_, err = st.Get("blockrewards", 100, &stored)
+		require.NoError(t, err)
+		assert.False(t, stored.RelayChecked)
+
+		// The relays know better than the registry: the stored result is computed again.
+		bid := &relay.DeliveredBid{Slot: 100, BlockHash: block.Hash().Hex(), Value: big.NewInt(7)}
+		svc.Relays = &mockRelayService{bid: bid}
+
+		result, err = svc.GetBlockReward(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+		assert.Equal(t, "mev", result.Status)
+		assert.True(t, result.RelayChecked)
+
+		_, err = st.Get("blockrewards", 100, &stored)
+		require.NoError(t, err)
+		assert.True(t, stored.RelayChecked)
+	})
+	t.Run("reorged out block is refused", func(t *testing.T) {
+		t.Parallel()
+
+		svc := blockreward.NewService(&mockExecClient{}, &mockBeaconService{reorged: true}, mockClock{}, nil, nil)
+
+		_, err := svc.GetBlockReward(context.Background(), beacon.BlockID("0xroot"))
+		require.ErrorIs(t, err, beacon.ErrBlockNotCanonical)
+	})
+}
@@ -0,0 +1,211 @@
+package builders
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	pkgerrors "github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Builder describes a known block builder and how to recognize its blocks.
+type Builder struct {
+	Name string `json:"name" yaml:"name"`
+	// ExtraDataPatterns are regular expressions matched against the block's extra data.
+	ExtraDataPatterns []string `json:"extra_data_patterns,omitempty" yaml:"extra_data_patterns"`
+	// FeeRecipients are the execution addresses the builder sets as block fee recipient.
+	FeeRecipients []string `json:"fee_recipients,omitempty" yaml:"fee_recipients"`
+	// Pubkeys are the BLS public keys the builder signs relay bids with.
+	Pubkeys []string `json:"pubkeys,omitempty" yaml:"pubkeys"`
+}
+
+// Block holds the block attributes a builder can be identified by.
+// Fields that are not known may be left empty.
+type Block struct {
+	BuilderPubkey string
+	ExtraData     []byte
+	FeeRecipient  common.Address
+}
+
+// registryFile is the on-disk layout of the registry.
+type registryFile struct {
+	Builders []Builder `json:"builders" yaml:"builders"`
+}
+
+// index is an immutable lookup structure built from a registry file.
+type index struct {
+	byPubkey       map[string]string
+	byFeeRecipient map[common.Address]string
+	patterns       []namedPattern
+	builders       []Builder
+}
+
+type namedPattern struct {
+	re   *regexp.Regexp
+	name string
+}
+
+// Registry maps builder public keys, fee recipients and extra-data patterns to builder names.
+// It is loaded from a JSON or YAML file and can be reloaded at runtime.
+type Registry struct {
+	idx  *index
+	path string
+	mu   sync.RWMutex
+}
+
+// LoadRegistry reads the registry from a JSON or YAML file, picked by file extension.
+// Without a path the registry is empty and identifies no builders.
+func LoadRegistry(path string) (*Registry, error) {
+	r := &Registry{idx: &index{}, path: path}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// NewRegistry builds an in-memory registry from the given builders.
+// Calling Reload on it is a no-op.
+func NewRegistry(builders []Builder) (*Registry, error) {
+	idx, err := newIndex(builders)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Registry{idx: idx}, nil
+}
+
+// Reload re-reads the registry file. On error the previously loaded builders are kept.
+func (r *Registry) Reload() error {
+	if r.path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return pkgerrors.Wrap(err, "read builder registry")
+	}
+
+	var file registryFile
+
+	switch strings.ToLower(filepath.Ext(r.path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	default:
+		err = json.Unmarshal(data, &file)
+	}
+
+	if err != nil {
+		return pkgerrors.Wrapf(err, "parse builder registry %s", r.path)
+	}
+
+	idx, err := newIndex(file.Builders)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "index builder registry %s", r.path)
+	}
+
+	r.mu.Lock()
+	r.idx = idx
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Builders returns the currently loaded builders.
+func (r *Registry) Builders() []Builder {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Builder{}, r.idx.builders...)
+}
+
+// Identify returns the name of the builder of a block. Builder public keys are the
+// most reliable signal, followed by the fee recipient and finally the extra data.
+func (r *Registry) Identify(block Block) (string, bool) {
+	r.mu.RLock()
+	idx := r.idx
+	r.mu.RUnlock()
+
+	if block.BuilderPubkey != "" {
+		if name, ok := idx.byPubkey[strings.ToLower(block.BuilderPubkey)]; ok {
+			return name, true
+		}
+	}
+
+	if name, ok := idx.byFeeRecipient[block.FeeRecipient]; ok {
+		return name, true
+	}
+
+	if len(block.ExtraData) == 0 {
+		return "", false
+	}
+
+	for _, p := range idx.patterns {
+		if p.re.Match(block.ExtraData) {
+			return p.name, true
+		}
+	}
+
+	return "", false
+}
+
+func newIndex(builders []Builder) (*index, error) {
+	idx := &index{
+		byPubkey:       make(map[string]string),
+		byFeeRecipient: make(map[common.Address]string),
+		builders:       builders,
+	}
+
+	names := make(map[string]bool, len(builders))
+
+	for _, b := range builders {
+		if b.Name == "" {
+			return nil, pkgerrors.New("builder without name")
+		}
+
+		if names[b.Name] {
+			return nil, pkgerrors.Errorf("duplicate builder %s", b.Name)
+		}
+
+		names[b.Name] = true
+
+		for _, pubkey := range b.Pubkeys {
+			key := strings.ToLower(pubkey)
+			if owner, ok := idx.byPubkey[key]; ok && owner != b.Name {
+				return nil, pkgerrors.Errorf("builder %s: pubkey %s already belongs to builder %s", b.Name, pubkey, owner)
+			}
+
+			idx.byPubkey[key] = b.Name
+		}
+
+		for _, addr := range b.FeeRecipients {
+			if !common.IsHexAddress(addr) {
+				return nil, pkgerrors.Errorf("builder %s: invalid fee recipient %q", b.Name, addr)
+			}
+
+			key := common.HexToAddress(addr)
+			if owner, ok := idx.byFeeRecipient[key]; ok && owner != b.Name {
+				return nil, pkgerrors.Errorf("builder %s: fee recipient %s already belongs to builder %s", b.Name, addr, owner)
+			}
+
+			idx.byFeeRecipient[key] = b.Name
+		}
+
+		for _, pattern := range b.ExtraDataPatterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, pkgerrors.Wrapf(err, "builder %s: invalid extra data pattern", b.Name)
+			}
+
+			idx.patterns = append(idx.patterns, namedPattern{re: re, name: b.Name})
+		}
+	}
+
+	return idx, nil
+}
@@ -0,0 +1,163 @@
+package builders_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/powerslider/ethereum-validator-api/pkg/builders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const registryJSON = `{"builders": [
+	{"name": "Titan", "extra_data_patterns": ["^Titan"], "pubkeys": ["0xAB12"]},
+	{"name": "beaverbuild", "fee_recipients": ["0x95222290DD7278Aa3Ddd389Cc1E1d165CC4BAfe5"]}
+]}`
+
+const registryYAML = `builders:
+  - name: Titan
+    extra_data_patterns:
+      - "^Titan"
+    pubkeys:
+      - "0xAB12"
+  - name: beaverbuild
+    fee_recipients:
+      - "0x95222290DD7278Aa3Ddd389Cc1E1d165CC4BAfe5"
+`
+
+func writeRegistry(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoadRegistry(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{name: "JSON", file: "builders.json", content: registryJSON},
+		{name: "YAML", file: "builders.yaml", content: registryYAML},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			registry, err := builders.LoadRegistry(writeRegistry(t, tt.file, tt.content))
+			require.NoError(t, err)
+			require.Len(t, registry.Builders(), 2)
+
+			// Public keys are matched case-insensitively and take precedence.
+			name, ok := registry.Identify(builders.Block{
+				BuilderPubkey: "0xab12",
+				FeeRecipient:  common.HexToAddress("0x95222290DD7278Aa3Ddd389Cc1E1d165CC4BAfe5"),
+			})
+			assert.True(t, ok)
+			assert.Equal(t, "Titan", name)
+
+			name, ok = registry.Identify(builders.Block{
+				FeeRecipient: common.HexToAddress("0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5"),
+			})
+			assert.True(t, ok)
+			assert.Equal(t, "beaverbuild", name)
+
+			name, ok = registry.Identify(builders.Block{ExtraData: []byte("Titan (titanbuilder.xyz)")})
+			assert.True(t, ok)
+			assert.Equal(t, "Titan", name)
+
+			_, ok = registry.Identify(builders.Block{ExtraData: []byte("unknown")})
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestLoadRegistryWithoutFile(t *testing.T) {
+	t.Parallel()
+
+	registry, err := builders.LoadRegistry("")
+	require.NoError(t, err)
+	require.NoError(t, registry.Reload())
+
+	assert.Empty(t, registry.Builders())
+
+	_, ok := registry.Identify(builders.Block{BuilderPubkey: "0xab12", ExtraData: []byte("Titan")})
+	assert.False(t, ok)
+}
+
+func TestRegistryReloadFailureKeepsBuilders(t *testing.T) {
+	t.Parallel()
+
+	path := writeRegistry(t, "builders.yaml", registryYAML)
+
+	registry, err := builders.LoadRegistry(path)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("builders: [\n"), 0o600))
+	require.Error(t, registry.Reload())
+
+	require.NoError(t, os.Remove(path))
+	require.Error(t, registry.Reload())
+
+	assert.Len(t, registry.Builders(), 2)
+
+	name, ok := registry.Identify(builders.Block{BuilderPubkey: "0xab12"})
+	assert.True(t, ok)
+	assert.Equal(t, "Titan", name)
+}
+
+func TestRegistryDuplicates(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name    string
+		file    string
+		content string
+		err     string
+	}{
+		{
+			name:    "YAML key",
+			file:    "builders.yaml",
+			content: "builders:\n  - name: Titan\n    name: beaverbuild\n",
+			err:     `mapping key "name" already defined`,
+		},
+		{
+			name:    "builder name",
+			file:    "builders.json",
+			content: `{"builders": [{"name": "Titan"}, {"name": "Titan"}]}`,
+			err:     "duplicate builder Titan",
+		},
+		{
+			name:    "pubkey",
+			file:    "builders.json",
+			content: `{"builders": [{"name": "Titan", "pubkeys": ["0xAB12"]}, {"name": "beaverbuild", "pubkeys": ["0xab12"]}]}`,
+			err:     "builder beaverbuild: pubkey 0xab12 already belongs to builder Titan",
+		},
+		{
+			name: "fee recipient",
+			file: "builders.json",
+			content: `{"builders": [
+				{"name": "Titan", "fee_recipients": ["0x95222290DD7278Aa3Ddd389Cc1E1d165CC4BAfe5"]},
+				{"name": "beaverbuild", "fee_recipients": ["0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5"]}
+			]}`,
+			err: "already belongs to builder Titan",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := builders.LoadRegistry(writeRegistry(t, tt.file, tt.content))
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	// A builder may list the same key twice.
+	_, err := builders.NewRegistry([]builders.Builder{{Name: "Titan", Pubkeys: []string{"0xab12", "0xAB12"}}})
+	require.NoError(t, err)
+}
@@ -0,0 +1,257 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+)
+
+// Upstream is the beacon API surface wrapped by BeaconService. It is satisfied by *beacon.Service.
+type Upstream interface {
+	GetBeaconHeader(ctx context.Context, id beacon.BlockID) (*beacon.BlockHeaderResponse, error)
+	GetBlockRewardFromConsensus(ctx context.Context, blockRoot string) (*beacon.RewardResponse, error)
+	GetExecutionPayload(ctx context.Context, blockID string) (*beacon.ExecutionPayload, error)
+	FetchSyncCommitteeIndexes(ctx context.Context, state beacon.StateID) ([]string, error)
+	FetchValidatorsByIDs(ctx context.Context, state beacon.StateID, ids []string) ([]beacon.ValidatorEntry, error)
+	GetSyncCommitteeRewards(ctx context.Context, id beacon.BlockID, ids []string) (*beacon.SyncCommitteeRewardsResponse, error)
+}
+
+// BeaconService caches beacon lookups in front of an Upstream. It satisfies
+// blockreward.BeaconService, syncduties.BeaconService and validatorrewards.SyncCommitteeService.
+//
+// Results for finalized blocks never change, so they are kept until evicted. Everything else,
+// as well as anything requested through a named identifier such as "head", expires after TTL.
+// Errors are never cached.
+type BeaconService struct {
+	Upstream Upstream
+	Cache    *LRU
+	TTL      time.Duration
+	// finalizedSlot is one past the highest slot seen finalized, zero while none was seen.
+	finalizedSlot atomic.Uint64
+}
+
+// NewBeaconService creates a caching beacon service holding at most size entries.
+// A size of zero disables caching.
+func NewBeaconService(upstream Upstream, size int, ttl time.Duration) *BeaconService {
+	return &BeaconService{
+		Upstream: upstream,
+		Cache:    NewLRU(size),
+		TTL:      ttl,
+	}
+}
+
+// Stats returns the hit, miss and eviction counters of the cache.
+func (s *BeaconService) Stats() Stats {
+	return s.Cache.Stats()
+}
+
+// GetBeaconHeader returns the cached block header or fetches it from upstream.
+func (s *BeaconService) GetBeaconHeader(ctx context.Context, id beacon.BlockID) (*beacon.BlockHeaderResponse, error) {
+	key := "header/" + string(id)
+
+	if v, ok := s.Cache.Get(key); ok {
+		return v.(*beacon.BlockHeaderResponse), nil //nolint:forcetypeassert // keys are typed by prefix
+	}
+
+	resp, err := s.Upstream.GetBeaconHeader(ctx, id)
+	if err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+
+	if resp.Finalized {
+		s.markFinalized(resp.Data.Header.Message.Slot)
+		// Also remember the header by root, which marks the block itself as finalized.
+		s.store("header/"+resp.Data.Root, resp, true)
+	}
+
+	s.store(key, resp, resp.Finalized && isFixed(string(id)))
+
+	return resp, nil
+}
+
+// GetBlockRewardFromConsensus returns the cached block reward or fetches it from upstream.
+func (s *BeaconService) GetBlockRewardFromConsensus(ctx context.Context, blockRoot string) (*beacon.RewardResponse, error) {
+	key := "reward/" + blockRoot
+
+	if v, ok := s.Cache.Get(key); ok {
+		return v.(*beacon.RewardResponse), nil //nolint:forcetypeassert // keys are typed by prefix
+	}
+
+	resp, err := s.Upstream.GetBlockRewardFromConsensus(ctx, blockRoot)
+	if err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+
+	s.store(key, resp, resp.Finalized && isFixed(blockRoot))
+
+	return resp, nil
+}
+
+// GetExecutionPayload returns the cached execution payload or fetches it from upstream.
+// The payload itself carries no finality, so it is kept indefinitely only when the block
+// is already known to be finalized from its header or reward.
+func (s *BeaconService) GetExecutionPayload(ctx context.Context, blockID string) (*beacon.ExecutionPayload, error) {
+	key := "payload/" + blockID
+
+	if v, ok := s.Cache.Get(key); ok {
+		return v.(*beacon.ExecutionPayload), nil //nolint:forcetypeassert // keys are typed by prefix
+	}
+
+	payload, err := s.Upstream.GetExecutionPayload(ctx, blockID)
+	if err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+
+	s.store(key, payload, s.isFinalizedBlock(blockID))
+
+	return payload, nil
+}
+
+// FetchSyncCommitteeIndexes returns the cached sync committee or fetches it from upstream.
+func (s *BeaconService) FetchSyncCommitteeIndexes(ctx context.Context, state beacon.StateID) ([]string, error) {
+	key := "synccommittee/" + string(state)
+
+	if v, ok := s.Cache.Get(key); ok {
+		return v.([]string), nil //nolint:forcetypeassert // keys are typed by prefix
+	}
+
+	indexes, err := s.Upstream.FetchSyncCommitteeIndexes(ctx, state)
+	if err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+
+	s.store(key, indexes, s.isFinalizedBlock(string(state)))
+
+	return indexes, nil
+}
+
+// FetchValidatorsByIDs returns the cached validators or fetches them from upstream.
+func (s *BeaconService) FetchValidatorsByIDs(
+	ctx context.Context,
+	state beacon.StateID,
+	ids []string,
+) ([]beacon.ValidatorEntry, error) {
+	key := "validators/" + string(state) + "/" + strings.Join(ids, ",")
+
+	if v, ok := s.Cache.Get(key); ok {
+		return v.([]beacon.ValidatorEntry), nil //nolint:forcetypeassert // keys are typed by prefix
+	}
+
+	validators, err := s.Upstream.FetchValidatorsByIDs(ctx, state, ids)
+	if err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+
+	s.store(key, validators, s.isFinalizedBlock(string(state)))
+
+	return validators, nil
+}
+
+// GetSyncCommitteeRewards returns the cached sync committee rewards or fetches them from upstream.
+func (s *BeaconService) GetSyncCommitteeRewards(
+	ctx context.Context,
+	id beacon.BlockID,
+	ids []string,
+) (*beacon.SyncCommitteeRewardsResponse, error) {
+	key := "syncrewards/" + string(id) + "/" + strings.Join(ids, ",")
+
+	if v, ok := s.Cache.Get(key); ok {
+		return v.(*beacon.SyncCommitteeRewardsResponse), nil //nolint:forcetypeassert // keys are typed by prefix
+	}
+
+	resp, err := s.Upstream.GetSyncCommitteeRewards(ctx, id, ids)
+	if err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+
+	s.store(key, resp, resp.Finalized && isFixed(string(id)))
+
+	return resp, nil
+}
+
+// InvalidateFrom removes the cached responses that may have changed in a reorg of fromSlot and
+// later slots: those looked up by such a slot, by a named identifier such as "head", and the
+// non-finalized headers looked up by root, whose canonical flag may have flipped. Responses
+// by root are otherwise immutable and kept.
+func (s *BeaconService) InvalidateFrom(fromSlot uint64) {
+	s.Cache.RemoveFunc(func(key string, value any) bool {
+		id := keyID(key)
+
+		if slot, ok := beacon.BlockID(id).Slot(); ok {
+			return slot >= fromSlot
+		}
+
+		if !isFixed(id) {
+			return true
+		}
+
+		header, ok := value.(*beacon.BlockHeaderResponse)
+
+		return ok && !header.Finalized
+	})
+}
+
+// store caches value forever when finalized, otherwise for TTL.
+func (s *BeaconService) store(key string, value any, finalized bool) {
+	switch {
+	case finalized:
+		s.Cache.Set(key, value, 0)
+	case s.TTL > 0:
+		s.Cache.Set(key, value, s.TTL)
+	}
+}
+
+// markFinalized records that slot, and therefore every slot before it, is finalized.
+func (s *BeaconService) markFinalized(slot uint64) {
+	for {
+		current := s.finalizedSlot.Load()
+		if slot+1 <= current || s.finalizedSlot.CompareAndSwap(current, slot+1) {
+			return
+		}
+	}
+}
+
+// isFinalizedBlock reports whether id (a slot or block root) is known to be finalized.
+func (s *BeaconService) isFinalizedBlock(id string) bool {
+	if slot, ok := beacon.BlockID(id).Slot(); ok {
+		return slot < s.finalizedSlot.Load()
+	}
+
+	if !isFixed(id) {
+		return false
+	}
+
+	//nolint:forcetypeassert // keys are typed by prefix
+	if v, ok := s.Cache.Peek("header/" + id); ok && v.(*beacon.BlockHeaderResponse).Finalized {
+		return true
+	}
+
+	v, ok := s.Cache.Peek("reward/" + id)
+
+	return ok && v.(*beacon.RewardResponse).Finalized //nolint:forcetypeassert // keys are typed by prefix
+}
+
+// keyID returns the block or state identifier of a cache key such as "validators/<state>/<ids>".
+func keyID(key string) string {
+	_, id, _ := strings.Cut(key, "/")
+	id, _, _ = strings.Cut(id, "/")
+
+	return id
+}
+
+// isFixed reports whether id always refers to the same block, i.e. it is a slot or a root
+// rather than a named identifier such as "head" or "finalized".
+func isFixed(id string) bool {
+	_, isSlot := beacon.BlockID(id).Slot()
+
+	return isSlot || strings.HasPrefix(id, "0x")
+}
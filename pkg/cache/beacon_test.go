@@ -0,0 +1,175 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockUpstream counts calls and reports slots up to finalizedSlot as finalized.
+type mockUpstream struct {
+	calls         map[string]int
+	finalizedSlot uint64
+}
+
+func (m *mockUpstream) GetBeaconHeader(ctx context.Context, id beacon.BlockID) (*beacon.BlockHeaderResponse, error) {
+	m.calls["header"]++
+
+	slot, _ := id.Slot()
+	resp := &beacon.BlockHeaderResponse{
+		Data:      beacon.BlockHeaderData{Root: "0xroot" + string(id)},
+		Finalized: slot <= m.finalizedSlot,
+	}
+	resp.Data.Header.Message.Slot = slot
+
+	return resp, nil
+}
+
+func (m *mockUpstream) GetBlockRewardFromConsensus(ctx context.Context, blockRoot string) (*beacon.RewardResponse, error) {
+	m.calls["reward"]++
+	return &beacon.RewardResponse{}, nil
+}
+
+func (m *mockUpstream) GetExecutionPayload(ctx context.Context, blockID string) (*beacon.ExecutionPayload, error) {
+	m.calls["payload"]++
+	return &beacon.ExecutionPayload{}, nil
+}
+
+func (m *mockUpstream) FetchSyncCommitteeIndexes(ctx context.Context, state beacon.StateID) ([]string, error) {
+	m.calls["synccommittee"]++
+	return []string{"1"}, nil
+}
+
+func (m *mockUpstream) FetchValidatorsByIDs(ctx context.Context, state beacon.StateID, ids []string) ([]beacon.ValidatorEntry, error) {
+	m.calls["validators"]++
+	return []beacon.ValidatorEntry{{Index: "1"}}, nil
+}
+
+func (m *mockUpstream) GetSyncCommitteeRewards(
+	ctx context.Context,
+	id beacon.BlockID,
+	ids []string,
+) (*beacon.SyncCommitteeRewardsResponse, error) {
+	m.calls["syncrewards"]++
+
+	slot, _ := id.Slot()
+
+	return &beacon.SyncCommitteeRewardsResponse{Finalized: slot <= m.finalizedSlot}, nil
+}
+
+func newCache(finalizedSlot uint64, size int) (*cache.BeaconService, *mockUpstream, *time.Time) {
+	upstream := &mockUpstream{calls: map[string]int{}, finalizedSlot: finalizedSlot}
+	now := time.Unix(0, 0)
+
+	svc := cache.NewBeaconService(upstream, size, time.Minute)
+	svc.Cache.Now = func() time.Time { return now }
+
+	return svc, upstream, &now
+}
+
+func TestBeaconServiceCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("finalized results never expire", func(t *testing.T) {
+		t.Parallel()
+
+		svc, upstream, now := newCache(100, 100)
+
+		_, err := svc.GetBeaconHeader(ctx, beacon.SlotBlockID(100))
+		require.NoError(t, err)
+		_, err = svc.FetchSyncCommitteeIndexes(ctx, beacon.SlotStateID(100))
+		require.NoError(t, err)
+		_, err = svc.GetSyncCommitteeRewards(ctx, beacon.SlotBlockID(100), []string{"1"})
+		require.NoError(t, err)
+
+		*now = now.Add(time.Hour)
+
+		_, err = svc.GetBeaconHeader(ctx, beacon.SlotBlockID(100))
+		require.NoError(t, err)
+		_, err = svc.FetchSyncCommitteeIndexes(ctx, beacon.SlotStateID(100))
+		require.NoError(t, err)
+		_, err = svc.GetSyncCommitteeRewards(ctx, beacon.SlotBlockID(100), []string{"1"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, upstream.calls["header"])
+		assert.Equal(t, 1, upstream.calls["synccommittee"])
+		assert.Equal(t, 1, upstream.calls["syncrewards"])
+	})
+
+	t.Run("non-finalized and named results expire", func(t *testing.T) {
+		t.Parallel()
+
+		svc, upstream, now := newCache(100, 100)
+
+		for _, id := range []beacon.BlockID{beacon.SlotBlockID(101), beacon.BlockFinalized} {
+			_, err := svc.GetBeaconHeader(ctx, id)
+			require.NoError(t, err)
+			_, err = svc.GetBeaconHeader(ctx, id)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 2, upstream.calls["header"])
+
+		*now = now.Add(time.Minute)
+
+		_, err := svc.GetBeaconHeader(ctx, beacon.SlotBlockID(101))
+		require.NoError(t, err)
+		assert.Equal(t, 3, upstream.calls["header"])
+
+		stats := svc.Stats()
+		assert.Equal(t, uint64(2), stats.Hits)
+		assert.Equal(t, uint64(3), stats.Misses)
+		assert.Equal(t, uint64(1), stats.Expired)
+	})
+
+	t.Run("evicts least recently used", func(t *testing.T) {
+		t.Parallel()
+
+		svc, upstream, _ := newCache(0, 2)
+
+		for _, root := range []string{"0x1", "0x2", "0x1", "0x3", "0x1", "0x2"} {
+			_, err := svc.GetBlockRewardFromConsensus(ctx, root)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 4, upstream.calls["reward"])
+
+		stats := svc.Stats()
+		assert.Equal(t, uint64(2), stats.Evictions)
+		assert.Equal(t, 2, stats.Entries)
+		assert.Equal(t, 2, stats.Capacity)
+	})
+	t.Run("reorg invalidates later slots", func(t *testing.T) {
+		t.Parallel()
+
+		svc, upstream, _ := newCache(100, 100)
+
+		fetch := func() {
+			for _, slot := range []uint64{100, 101, 102} {
+				_, err := svc.GetBeaconHeader(ctx, beacon.SlotBlockID(slot))
+				require.NoError(t, err)
+			}
+
+			_, err := svc.GetBeaconHeader(ctx, beacon.BlockHead)
+			require.NoError(t, err)
+			_, err = svc.GetBlockRewardFromConsensus(ctx, "0xroot102")
+			require.NoError(t, err)
+		}
+
+		fetch()
+		svc.InvalidateFrom(102)
+		fetch()
+
+		// Slot 102 and head are fetched again, slots before the reorg and rewards by root are not.
+		assert.Equal(t, 6, upstream.calls["header"])
+		assert.Equal(t, 1, upstream.calls["reward"])
+		assert.Equal(t, uint64(2), svc.Stats().Invalidated)
+	})
+}
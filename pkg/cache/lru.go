@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Stats reports the usage of a cache.
+type Stats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Expired   uint64 `json:"expired"`
+	// Invalidated counts the entries removed because they became stale, e.g. after a reorg.
+	Invalidated uint64 `json:"invalidated"`
+	Entries     int    `json:"entries"`
+	Capacity    int    `json:"capacity"`
+}
+
+// LRU is a size-bounded least recently used cache whose entries may expire.
+// It is safe for concurrent use. A capacity of zero disables caching.
+type LRU struct {
+	// Now returns the current time, time.Now when nil.
+	Now   func() time.Time
+	items map[string]*list.Element
+	order *list.List
+	stats Stats
+	mu    sync.Mutex
+}
+
+type lruEntry struct {
+	// expires is the zero time for entries that never expire.
+	expires time.Time
+	value   any
+	key     string
+}
+
+// NewLRU creates a cache holding at most capacity entries.
+func NewLRU(capacity int) *LRU {
+	return &LRU{
+		items: make(map[string]*list.Element, max(capacity, 0)),
+		order: list.New(),
+		stats: Stats{Capacity: max(capacity, 0)},
+	}
+}
+
+// Get returns the value stored under key, if present and not expired.
+func (c *LRU) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+
+	entry := elem.Value.(*lruEntry) //nolint:forcetypeassert // only *lruEntry values are stored
+
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		c.remove(elem)
+		c.stats.Expired++
+		c.stats.Misses++
+
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	c.stats.Hits++
+
+	return entry.value, true
+}
+
+// Peek returns the value stored under key like Get, without counting a hit or miss
+// or refreshing the entry.
+func (c *LRU) Peek(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*lruEntry) //nolint:forcetypeassert // only *lruEntry values are stored
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+// Set stores value under key. A zero ttl keeps the entry until it is evicted.
+// The least recently used entry is evicted when the cache is full.
+func (c *LRU) Set(key string, value any, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stats.Capacity == 0 {
+		return
+	}
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = c.now().Add(ttl)
+	}
+
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*lruEntry) //nolint:forcetypeassert // only *lruEntry values are stored
+		entry.value = value
+		entry.expires = expires
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	if c.order.Len() >= c.stats.Capacity {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+}
+
+// RemoveFunc removes every entry for which stale returns true and returns how many were removed.
+func (c *LRU) RemoveFunc(stale func(key string, value any) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+
+	for elem := c.order.Front(); elem != nil; {
+		next := elem.Next()
+
+		entry := elem.Value.(*lruEntry) //nolint:forcetypeassert // only *lruEntry values are stored
+		if stale(entry.key, entry.value) {
+			c.remove(elem)
+			removed++
+		}
+
+		elem = next
+	}
+
+	c.stats.Invalidated += uint64(removed) //nolint:gosec // removed is never negative
+
+	return removed
+}
+
+// Stats returns a snapshot of the cache usage.
+func (c *LRU) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.order.Len()
+
+	return stats
+}
+
+func (c *LRU) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*lruEntry).key) //nolint:forcetypeassert // only *lruEntry values are stored
+}
+
+func (c *LRU) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+
+	return time.Now()
+}
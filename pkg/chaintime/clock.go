@@ -0,0 +1,172 @@
+package chaintime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+)
+
+var (
+	ErrBeforeGenesis   = errors.New("time is before genesis")
+	ErrSlotOutOfRange  = errors.New("slot is out of range")
+	ErrMissingSpecItem = errors.New("missing spec value")
+)
+
+// BeaconService is the subset of the beacon service used to load the chain parameters.
+type BeaconService interface {
+	GetGenesis(ctx context.Context) (*beacon.GenesisResponse, error)
+	GetSpec(ctx context.Context) (*beacon.SpecResponse, error)
+}
+
+// SlotTime describes a slot and where it falls in the chain's calendar.
+type SlotTime struct {
+	// Start is the wall-clock time at which the slot begins.
+	Start               time.Time
+	Slot                uint64
+	Epoch               uint64
+	SyncCommitteePeriod uint64
+}
+
+// Clock converts between slots, epochs, sync committee periods and wall-clock time.
+// The chain parameters never change, so they are loaded once and conversions are pure arithmetic.
+type Clock struct {
+	// Now returns the current time, time.Now when nil.
+	Now                          func() time.Time
+	GenesisTime                  time.Time
+	SecondsPerSlot               uint64
+	SlotsPerEpoch                uint64
+	EpochsPerSyncCommitteePeriod uint64
+}
+
+// NewClock creates a clock for a chain with the given genesis time and spec constants.
+func NewClock(genesisTime time.Time, secondsPerSlot, slotsPerEpoch, epochsPerSyncCommitteePeriod uint64) *Clock {
+	return &Clock{
+		GenesisTime:                  genesisTime,
+		SecondsPerSlot:               secondsPerSlot,
+		SlotsPerEpoch:                slotsPerEpoch,
+		EpochsPerSyncCommitteePeriod: epochsPerSyncCommitteePeriod,
+	}
+}
+
+// Load creates a clock from the genesis time and spec reported by the beacon node.
+func Load(ctx context.Context, svc BeaconService) (*Clock, error) {
+	genesis, err := svc.GetGenesis(ctx)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch genesis")
+	}
+
+	spec, err := svc.GetSpec(ctx)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch spec")
+	}
+
+	var values [3]uint64
+
+	for i, name := range []string{"SECONDS_PER_SLOT", "SLOTS_PER_EPOCH", "EPOCHS_PER_SYNC_COMMITTEE_PERIOD"} {
+		values[i], err = specUint(spec, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	genesisTime := time.Unix(int64(genesis.Data.GenesisTime), 0).UTC() //nolint:gosec // genesis times fit in int64
+
+	return NewClock(genesisTime, values[0], values[1], values[2]), nil
+}
+
+// specUint parses a positive integer spec constant.
+func specUint(spec *beacon.SpecResponse, name string) (uint64, error) {
+	raw, ok := spec.Data[name]
+	if !ok {
+		return 0, pkgerrors.Wrap(ErrMissingSpecItem, name)
+	}
+
+	var str string
+	if err := json.Unmarshal(raw, &str); err != nil {
+		return 0, pkgerrors.Wrapf(err, "parse spec value %s", name)
+	}
+
+	value, err := strconv.ParseUint(str, 10, 64)
+	if err != nil || value == 0 {
+		return 0, pkgerrors.Errorf("invalid spec value %s=%q", name, str)
+	}
+
+	return value, nil
+}
+
+// CurrentSlot returns the slot in progress at the current time, 0 before genesis.
+func (c *Clock) CurrentSlot() uint64 {
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+
+	slot, err := c.SlotAt(now())
+	if err != nil {
+		return 0
+	}
+
+	return slot
+}
+
+// IsFuture reports whether slot has not started yet.
+func (c *Clock) IsFuture(slot uint64) bool {
+	return slot > c.CurrentSlot()
+}
+
+// SlotAt returns the slot in progress at t. Returns ErrBeforeGenesis when t is before genesis.
+func (c *Clock) SlotAt(t time.Time) (uint64, error) {
+	if t.Before(c.GenesisTime) {
+		return 0, pkgerrors.Wrapf(ErrBeforeGenesis, "genesis is at %s", c.GenesisTime.Format(time.RFC3339))
+	}
+
+	return uint64(t.Sub(c.GenesisTime)/time.Second) / c.SecondsPerSlot, nil
+}
+
+// SlotStart returns the wall-clock time at which slot begins.
+// Returns ErrSlotOutOfRange when the time cannot be represented.
+func (c *Clock) SlotStart(slot uint64) (time.Time, error) {
+	genesis := uint64(c.GenesisTime.Unix()) //nolint:gosec // genesis is never before 1970
+
+	if slot > (math.MaxInt64-genesis)/c.SecondsPerSlot {
+		return time.Time{}, pkgerrors.Wrapf(ErrSlotOutOfRange, "slot %d", slot)
+	}
+
+	return time.Unix(int64(genesis+slot*c.SecondsPerSlot), 0).UTC(), nil //nolint:gosec // bounds checked above
+}
+
+// EpochOf returns the epoch containing slot.
+func (c *Clock) EpochOf(slot uint64) uint64 {
+	return slot / c.SlotsPerEpoch
+}
+
+// EpochStartSlot returns the first slot of epoch.
+func (c *Clock) EpochStartSlot(epoch uint64) uint64 {
+	return epoch * c.SlotsPerEpoch
+}
+
+// SyncCommitteePeriodOf returns the sync committee period containing slot.
+func (c *Clock) SyncCommitteePeriodOf(slot uint64) uint64 {
+	return c.EpochOf(slot) / c.EpochsPerSyncCommitteePeriod
+}
+
+// SlotTime returns the epoch, sync committee period and start time of slot.
+func (c *Clock) SlotTime(slot uint64) (SlotTime, error) {
+	start, err := c.SlotStart(slot)
+	if err != nil {
+		return SlotTime{}, err
+	}
+
+	return SlotTime{
+		Slot:                slot,
+		Epoch:               c.EpochOf(slot),
+		SyncCommitteePeriod: c.SyncCommitteePeriodOf(slot),
+		Start:               start,
+	}, nil
+}
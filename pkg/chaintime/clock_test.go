@@ -0,0 +1,78 @@
+package chaintime_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/chaintime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mainnetGenesis is the genesis time of Ethereum mainnet.
+var mainnetGenesis = time.Unix(1606824023, 0).UTC()
+
+type mockBeaconService struct{}
+
+func (mockBeaconService) GetGenesis(ctx context.Context) (*beacon.GenesisResponse, error) {
+	return &beacon.GenesisResponse{Data: beacon.GenesisData{GenesisTime: uint64(mainnetGenesis.Unix())}}, nil
+}
+
+func (mockBeaconService) GetSpec(ctx context.Context) (*beacon.SpecResponse, error) {
+	return &beacon.SpecResponse{Data: map[string]json.RawMessage{
+		"SECONDS_PER_SLOT":                 json.RawMessage(`"12"`),
+		"SLOTS_PER_EPOCH":                  json.RawMessage(`"32"`),
+		"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": json.RawMessage(`"256"`),
+		"BLOB_SCHEDULE":                    json.RawMessage(`[{"EPOCH":"412672","MAX_BLOBS_PER_BLOCK":"15"}]`),
+	}}, nil
+}
+
+func TestClock(t *testing.T) {
+	t.Parallel()
+
+	clock, err := chaintime.Load(context.Background(), mockBeaconService{})
+	require.NoError(t, err)
+
+	t.Run("slot to time", func(t *testing.T) {
+		t.Parallel()
+
+		slotTime, err := clock.SlotTime(11591367)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(362230), slotTime.Epoch)
+		assert.Equal(t, uint64(1414), slotTime.SyncCommitteePeriod)
+		assert.Equal(t, mainnetGenesis.Add(11591367*12*time.Second), slotTime.Start)
+	})
+
+	t.Run("time to slot", func(t *testing.T) {
+		t.Parallel()
+
+		slot, err := clock.SlotAt(mainnetGenesis.Add(100*12*time.Second + 11*time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(100), slot)
+
+		_, err = clock.SlotAt(mainnetGenesis.Add(-time.Second))
+		require.ErrorIs(t, err, chaintime.ErrBeforeGenesis)
+	})
+
+	t.Run("future slots", func(t *testing.T) {
+		t.Parallel()
+
+		fixed := *clock
+		fixed.Now = func() time.Time { return mainnetGenesis.Add(10 * 12 * time.Second) }
+
+		assert.Equal(t, uint64(10), fixed.CurrentSlot())
+		assert.False(t, fixed.IsFuture(10))
+		assert.True(t, fixed.IsFuture(11))
+	})
+
+	t.Run("slot out of range", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := clock.SlotTime(1 << 62)
+		require.ErrorIs(t, err, chaintime.ErrSlotOutOfRange)
+	})
+}
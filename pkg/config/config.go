@@ -1,14 +1,122 @@
 package config
 
 import (
+	"strings"
+	"time"
+
 	"github.com/joeshaw/envdecode"
 	pkgerrors "github.com/pkg/errors"
 )
 
 type Config struct {
-	RPCEndpoint string `env:"RPC_ENDPOINT,required"`
-	ServerHost  string `env:"SERVER_HOST,default:0.0.0.0"`
-	ServerPort  int    `env:"SERVER_PORT,default:8080"`
+	// RPCEndpoint is the fallback for providers serving both the beacon and execution APIs on one URL.
+	RPCEndpoint string `env:"RPC_ENDPOINT"`
+
+	// BeaconEndpoints lists one or more beacon nodes (semicolon separated) to fail over between.
+	BeaconEndpoints             []string      `env:"BEACON_ENDPOINT"`
+	BeaconTLSCAFile             string        `env:"BEACON_TLS_CA_FILE"`
+	BeaconTLSCertFile           string        `env:"BEACON_TLS_CERT_FILE"`
+	BeaconTLSKeyFile            string        `env:"BEACON_TLS_KEY_FILE"`
+	BeaconHeaders               []string      `env:"BEACON_HEADERS"`
+	BeaconTimeout               time.Duration `env:"BEACON_TIMEOUT,default=30s"`
+	BeaconHealthCheckInterval   time.Duration `env:"BEACON_HEALTH_CHECK_INTERVAL,default=15s"`
+	BeaconTLSInsecureSkipVerify bool          `env:"BEACON_TLS_INSECURE_SKIP_VERIFY"`
+	// BeaconMaxInFlight caps the requests in flight to the beacon nodes; zero removes the cap.
+	BeaconMaxInFlight int64 `env:"BEACON_MAX_IN_FLIGHT,default=64"`
+
+	ExecutionEndpoint              string        `env:"EXECUTION_ENDPOINT"`
+	ExecutionTLSCAFile             string        `env:"EXECUTION_TLS_CA_FILE"`
+	ExecutionTLSCertFile           string        `env:"EXECUTION_TLS_CERT_FILE"`
+	ExecutionTLSKeyFile            string        `env:"EXECUTION_TLS_KEY_FILE"`
+	ExecutionHeaders               []string      `env:"EXECUTION_HEADERS"`
+	ExecutionTimeout               time.Duration `env:"EXECUTION_TIMEOUT,default=30s"`
+	ExecutionTLSInsecureSkipVerify bool          `env:"EXECUTION_TLS_INSECURE_SKIP_VERIFY"`
+
+	// RelayEndpoints lists MEV-Boost relays (semicolon separated) queried for delivered payloads.
+	RelayEndpoints []string      `env:"RELAY_ENDPOINTS"`
+	RelayTimeout   time.Duration `env:"RELAY_TIMEOUT,default=5s"`
+
+	// BlockRewardMaxRange caps the number of slots of a /blockreward range request.
+	BlockRewardMaxRange    uint64 `env:"BLOCKREWARD_MAX_RANGE,default=7200"`
+	BlockRewardConcurrency int    `env:"BLOCKREWARD_CONCURRENCY,default=8"`
+
+	// ValidatorRewardsMaxEpochs caps the number of epochs of a /validators/{id}/rewards request.
+	ValidatorRewardsMaxEpochs uint64 `env:"VALIDATOR_REWARDS_MAX_EPOCHS,default=32"`
+
+	// ReadyMaxHeadLag is the number of slots the beacon and execution heads may trail the
+	// current slot for /readyz to report ready.
+	ReadyMaxHeadLag uint64        `env:"READY_MAX_HEAD_LAG,default=4"`
+	ReadyTimeout    time.Duration `env:"READY_TIMEOUT,default=5s"`
+
+	// CacheSize is the number of beacon responses kept in memory; zero disables caching.
+	// Responses for finalized blocks are kept until evicted, others for CacheTTL.
+	CacheSize int           `env:"CACHE_SIZE,default=10000"`
+	CacheTTL  time.Duration `env:"CACHE_TTL,default=12s"`
+
+	// StorePath is the database file persisting computed rewards and duties; empty disables it.
+	StorePath string `env:"STORE_PATH,default=data/validator-api.db"`
+
+	// IndexerEnabled starts a background indexer precomputing the rewards and duties of new slots.
+	IndexerEnabled bool `env:"INDEXER_ENABLED,default=false"`
+	// EventsEnabled serves the beacon node event stream at /api/v1/events.
+	EventsEnabled bool `env:"EVENTS_ENABLED,default=false"`
+
+	// BuilderRegistryFile is a JSON or YAML file mapping builder identifiers to builder names.
+	BuilderRegistryFile string `env:"BUILDER_REGISTRY_FILE,default=config/builders.yaml"`
+	// AdminToken guards the admin endpoints, sent as a bearer token; empty disables them.
+	AdminToken string `env:"ADMIN_TOKEN"`
+
+	ServerHost string `env:"SERVER_HOST,default=0.0.0.0"`
+	ServerPort int    `env:"SERVER_PORT,default=8080"`
+
+	// MetricsPath is where Prometheus metrics are served. They are served on the API port
+	// unless MetricsPort is set, in which case a separate listener is started on ServerHost.
+	MetricsPath string `env:"METRICS_PATH,default=/metrics"`
+	MetricsPort int    `env:"METRICS_PORT,default=0"`
+
+	// TracingEndpoint is the OTLP/HTTP collector URL traces are exported to; empty disables tracing.
+	TracingEndpoint string `env:"TRACING_ENDPOINT"`
+
+	// LogLevel is the minimum level logged: debug, info, warn or error.
+	LogLevel string `env:"LOG_LEVEL,default=info"`
+	// LogFormat is the log output format: json or text.
+	LogFormat string `env:"LOG_FORMAT,default=json"`
+
+	// RateLimitRPS and RateLimitBurst are the token bucket of each client on each route;
+	// a zero RateLimitRPS disables rate limiting. RateLimitRoutes overrides them per route
+	// template with "template=rps:burst" pairs (semicolon separated).
+	RateLimitRoutes []string `env:"RATE_LIMIT_ROUTES"`
+	RateLimitRPS    float64  `env:"RATE_LIMIT_RPS,default=10"`
+	RateLimitBurst  int      `env:"RATE_LIMIT_BURST,default=20"`
+	// RateLimitAPIKeys are the API keys (semicolon separated) clients are identified by, sent in
+	// RateLimitKeyHeader. Clients without one of them are identified by IP address.
+	RateLimitAPIKeys   []string `env:"RATE_LIMIT_API_KEYS"`
+	RateLimitKeyHeader string   `env:"RATE_LIMIT_KEY_HEADER,default=X-API-Key"`
+	// RateLimitTrustedProxies are the reverse proxy addresses or CIDR ranges (semicolon separated)
+	// whose X-Forwarded-For header is believed.
+	RateLimitTrustedProxies []string `env:"RATE_LIMIT_TRUSTED_PROXIES"`
+	// RateLimitMaxClients caps the client and route buckets kept in memory.
+	RateLimitMaxClients int `env:"RATE_LIMIT_MAX_CLIENTS,default=100000"`
+}
+
+// EndpointConfig describes how to connect to an upstream node or set of equivalent nodes.
+type EndpointConfig struct {
+	// Headers are sent with every request, e.g. for auth tokens.
+	Headers map[string]string
+	TLS     TLSConfig
+	// URLs holds the node URLs sharing these settings, in order of preference.
+	URLs    []string
+	Timeout time.Duration
+}
+
+// TLSConfig holds the TLS options of an upstream connection.
+type TLSConfig struct {
+	// CAFile is a PEM bundle used instead of the system roots to verify the server.
+	CAFile string
+	// CertFile and KeyFile are the client certificate for mutual TLS.
+	CertFile           string
+	KeyFile            string
+	InsecureSkipVerify bool
 }
 
 func Load() (*Config, error) {
@@ -18,5 +126,77 @@ func Load() (*Config, error) {
 		return nil, pkgerrors.Wrap(err, "decode env")
 	}
 
+	if len(cfg.BeaconEndpoints) == 0 && cfg.RPCEndpoint != "" {
+		cfg.BeaconEndpoints = []string{cfg.RPCEndpoint}
+	}
+
+	if cfg.ExecutionEndpoint == "" {
+		cfg.ExecutionEndpoint = cfg.RPCEndpoint
+	}
+
+	if len(cfg.BeaconEndpoints) == 0 || cfg.ExecutionEndpoint == "" {
+		return nil, pkgerrors.New("BEACON_ENDPOINT and EXECUTION_ENDPOINT (or RPC_ENDPOINT as fallback) must be set")
+	}
+
+	if cfg.BeaconHealthCheckInterval <= 0 {
+		return nil, pkgerrors.Errorf("BEACON_HEALTH_CHECK_INTERVAL must be positive, got %s", cfg.BeaconHealthCheckInterval)
+	}
+
 	return &cfg, nil
 }
+
+// Beacon returns the connection settings of the consensus-layer (beacon) node.
+func (c *Config) Beacon() (EndpointConfig, error) {
+	headers, err := parseHeaders(c.BeaconHeaders)
+	if err != nil {
+		return EndpointConfig{}, pkgerrors.Wrap(err, "parse BEACON_HEADERS")
+	}
+
+	return EndpointConfig{
+		URLs:    c.BeaconEndpoints,
+		Timeout: c.BeaconTimeout,
+		Headers: headers,
+		TLS: TLSConfig{
+			CAFile:             c.BeaconTLSCAFile,
+			CertFile:           c.BeaconTLSCertFile,
+			KeyFile:            c.BeaconTLSKeyFile,
+			InsecureSkipVerify: c.BeaconTLSInsecureSkipVerify,
+		},
+	}, nil
+}
+
+// Execution returns the connection settings of the execution-layer node.
+func (c *Config) Execution() (EndpointConfig, error) {
+	headers, err := parseHeaders(c.ExecutionHeaders)
+	if err != nil {
+		return EndpointConfig{}, pkgerrors.Wrap(err, "parse EXECUTION_HEADERS")
+	}
+
+	return EndpointConfig{
+		URLs:    []string{c.ExecutionEndpoint},
+		Timeout: c.ExecutionTimeout,
+		Headers: headers,
+		TLS: TLSConfig{
+			CAFile:             c.ExecutionTLSCAFile,
+			CertFile:           c.ExecutionTLSCertFile,
+			KeyFile:            c.ExecutionTLSKeyFile,
+			InsecureSkipVerify: c.ExecutionTLSInsecureSkipVerify,
+		},
+	}, nil
+}
+
+// parseHeaders parses "Name=value" pairs (semicolon separated in the environment).
+func parseHeaders(pairs []string) (map[string]string, error) {
+	headers := make(map[string]string, len(pairs))
+
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, pkgerrors.Errorf("invalid header %q, expected Name=value", pair)
+		}
+
+		headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+
+	return headers, nil
+}
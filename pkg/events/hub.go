@@ -0,0 +1,118 @@
+package events
+
+import (
+	"sync"
+)
+
+// Message is an event delivered to subscribers.
+type Message struct {
+	Data  any
+	Topic string
+}
+
+// subscriber is a single consumer of the hub and the topics it is interested in.
+type subscriber struct {
+	ch     chan Message
+	topics map[string]bool
+}
+
+// Hub fans out published messages to its subscribers. It is safe for concurrent use.
+type Hub struct {
+	subscribers map[*subscriber]struct{}
+	bufferSize  int
+	mu          sync.Mutex
+	closed      bool
+}
+
+// NewHub creates a new hub buffering up to bufferSize messages per subscriber.
+func NewHub(bufferSize int) *Hub {
+	return &Hub{
+		subscribers: make(map[*subscriber]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Subscribe returns a channel receiving the messages of the given topics, along with a function
+// that unsubscribes. The channel is closed on unsubscribe, when the hub is closed, or when the
+// subscriber falls more than the buffer size behind, so a slow consumer never blocks the others.
+func (h *Hub) Subscribe(topics []string) (<-chan Message, func()) {
+	sub := &subscriber{
+		ch:     make(chan Message, h.bufferSize),
+		topics: make(map[string]bool, len(topics)),
+	}
+
+	for _, topic := range topics {
+		sub.topics[topic] = true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+
+	h.subscribers[sub] = struct{}{}
+
+	return sub.ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		h.remove(sub)
+	}
+}
+
+// Publish delivers msg to the subscribers of its topic without blocking.
+func (h *Hub) Publish(msg Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		if !sub.topics[msg.Topic] {
+			continue
+		}
+
+		select {
+		case sub.ch <- msg:
+		default:
+			h.remove(sub)
+		}
+	}
+}
+
+// HasSubscribers reports whether anyone is subscribed to topic.
+func (h *Hub) HasSubscribers(topic string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		if sub.topics[topic] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Close disconnects all subscribers. Later subscriptions receive a closed channel.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+
+	for sub := range h.subscribers {
+		h.remove(sub)
+	}
+}
+
+// remove closes the channel of sub unless it was removed already. h.mu must be held.
+func (h *Hub) remove(sub *subscriber) {
+	if _, ok := h.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(h.subscribers, sub)
+	close(sub.ch)
+}
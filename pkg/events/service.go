@@ -0,0 +1,132 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"sync"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/blockreward"
+)
+
+// TopicBlockReward carries the block reward of every new block once it is computed.
+const TopicBlockReward = "block_reward"
+
+// Topics are all the topics clients can subscribe to.
+var Topics = []string{
+	beacon.TopicHead,
+	beacon.TopicBlock,
+	beacon.TopicFinalizedCheckpoint,
+	beacon.TopicChainReorg,
+	TopicBlockReward,
+}
+
+// subscriberBuffer is the number of messages a subscriber may lag behind before it is disconnected.
+const subscriberBuffer = 64
+
+// EventSource streams beacon node events. It is satisfied by *beacon.Service.
+type EventSource interface {
+	SubscribeEvents(ctx context.Context, topics ...string) <-chan beacon.Event
+}
+
+// BlockRewardService computes the block reward of a block.
+type BlockRewardService interface {
+	GetBlockReward(ctx context.Context, id beacon.BlockID) (*blockreward.Result, error)
+}
+
+// Hook is called with every event received from the beacon node.
+type Hook func(event beacon.Event)
+
+// Service relays the beacon node event stream to subscribers, adding the block reward of
+// every new block as it is computed while there are block_reward subscribers. Hooks get the
+// events of the same stream, so that a single subscription to the beacon node serves all
+// in-process consumers.
+type Service struct {
+	Source       EventSource
+	BlockRewards BlockRewardService
+	Hub          *Hub
+	hooks        []Hook
+	mu           sync.RWMutex
+}
+
+// NewService creates a new event service.
+func NewService(source EventSource, rewards BlockRewardService) *Service {
+	return &Service{
+		Source:       source,
+		BlockRewards: rewards,
+		Hub:          NewHub(subscriberBuffer),
+	}
+}
+
+// IsTopic reports whether clients can subscribe to topic.
+func IsTopic(topic string) bool {
+	return slices.Contains(Topics, topic)
+}
+
+// OnEvent registers a hook called on every event, in the order events are received. Hooks run
+// on the relaying goroutine and must not block.
+func (s *Service) OnEvent(hook Hook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hooks = append(s.hooks, hook)
+}
+
+// Subscribe returns a channel receiving the messages of the given topics and a function that unsubscribes.
+func (s *Service) Subscribe(topics []string) (<-chan Message, func()) {
+	return s.Hub.Subscribe(topics)
+}
+
+// Run relays events until ctx is canceled, then disconnects all subscribers.
+func (s *Service) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	defer func() {
+		wg.Wait()
+		s.Hub.Close()
+	}()
+
+	for event := range s.Source.SubscribeEvents(ctx, beacon.EventTopics...) {
+		s.Hub.Publish(Message{Topic: event.Topic(), Data: event})
+		s.runHooks(event)
+
+		// The reward takes a few upstream requests; compute it only when someone is listening,
+		// and without holding up other events.
+		if block, ok := event.(*beacon.BlockEvent); ok && s.Hub.HasSubscribers(TopicBlockReward) {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				s.publishBlockReward(ctx, block)
+			}()
+		}
+	}
+}
+
+// runHooks calls the registered hooks with event.
+func (s *Service) runHooks(event beacon.Event) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, hook := range s.hooks {
+		hook(event)
+	}
+}
+
+// publishBlockReward computes the reward of a new block and publishes it.
+func (s *Service) publishBlockReward(ctx context.Context, block *beacon.BlockEvent) {
+	result, err := s.BlockRewards.GetBlockReward(ctx, beacon.BlockID(block.Block))
+
+	switch cause := pkgerrors.Cause(err); {
+	case err == nil:
+		s.Hub.Publish(Message{Topic: TopicBlockReward, Data: result})
+	case errors.Is(cause, beacon.ErrNoExecutionPayload),
+		errors.Is(cause, beacon.ErrBlockNotCanonical),
+		ctx.Err() != nil:
+	default:
+		log.Printf("[Events] compute block reward of slot %d: %v", block.Slot, err)
+	}
+}
@@ -0,0 +1,173 @@
+package events_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/blockreward"
+	"github.com/powerslider/ethereum-validator-api/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSource struct {
+	events chan beacon.Event
+}
+
+func (m *mockSource) SubscribeEvents(ctx context.Context, topics ...string) <-chan beacon.Event {
+	return m.events
+}
+
+type mockRewards struct{}
+
+func (mockRewards) GetBlockReward(ctx context.Context, id beacon.BlockID) (*blockreward.Result, error) {
+	if id == "0xpremerge" {
+		return nil, pkgerrors.Wrap(beacon.ErrNoExecutionPayload, "phase0 block")
+	}
+
+	return &blockreward.Result{BlockRoot: string(id)}, nil
+}
+
+func TestServiceRelaysEvents(t *testing.T) {
+	t.Parallel()
+
+	source := &mockSource{events: make(chan beacon.Event)}
+	svc := events.NewService(source, mockRewards{})
+
+	rewards, unsubscribeRewards := svc.Subscribe([]string{events.TopicBlockReward})
+	defer unsubscribeRewards()
+
+	heads, unsubscribeHeads := svc.Subscribe([]string{beacon.TopicHead})
+	defer unsubscribeHeads()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		svc.Run(context.Background())
+	}()
+
+	source.events <- &beacon.BlockEvent{Slot: 1, Block: "0xpremerge"}
+	source.events <- &beacon.HeadEvent{Slot: 1, Block: "0xpremerge"}
+	source.events <- &beacon.BlockEvent{Slot: 2, Block: "0xbb"}
+	close(source.events)
+
+	<-done
+
+	msg := <-heads
+	assert.Equal(t, beacon.TopicHead, msg.Topic)
+	assert.Equal(t, &beacon.HeadEvent{Slot: 1, Block: "0xpremerge"}, msg.Data)
+
+	// Pre-Merge blocks have no reward to publish.
+	msg = <-rewards
+	assert.Equal(t, events.TopicBlockReward, msg.Topic)
+	assert.Equal(t, &blockreward.Result{BlockRoot: "0xbb"}, msg.Data)
+
+	// The subscriptions end when the service stops.
+	_, ok := <-rewards
+	assert.False(t, ok)
+
+	_, ok = <-heads
+	assert.False(t, ok)
+
+	closed, _ := svc.Subscribe(events.Topics)
+	_, ok = <-closed
+	require.False(t, ok)
+}
+
+// countingRewards counts the block rewards computed.
+type countingRewards struct {
+	calls atomic.Int32
+}
+
+func (m *countingRewards) GetBlockReward(ctx context.Context, id beacon.BlockID) (*blockreward.Result, error) {
+	m.calls.Add(1)
+
+	return &blockreward.Result{BlockRoot: string(id)}, nil
+}
+
+func TestServiceSkipsRewardsWithoutSubscribers(t *testing.T) {
+	t.Parallel()
+
+	source := &mockSource{events: make(chan beacon.Event)}
+	rewards := &countingRewards{}
+	svc := events.NewService(source, rewards)
+
+	blocks, unsubscribe := svc.Subscribe([]string{beacon.TopicBlock})
+	defer unsubscribe()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		svc.Run(context.Background())
+	}()
+
+	source.events <- &beacon.BlockEvent{Slot: 1, Block: "0xaa"}
+	close(source.events)
+
+	<-done
+
+	msg := <-blocks
+	assert.Equal(t, beacon.TopicBlock, msg.Topic)
+	assert.Equal(t, int32(0), rewards.calls.Load())
+}
+
+func TestHubDisconnectsSlowSubscribers(t *testing.T) {
+	t.Parallel()
+
+	hub := events.NewHub(1)
+
+	messages, unsubscribe := hub.Subscribe([]string{beacon.TopicHead})
+	defer unsubscribe()
+
+	hub.Publish(events.Message{Topic: beacon.TopicHead, Data: 1})
+	hub.Publish(events.Message{Topic: beacon.TopicBlock, Data: 2})
+	hub.Publish(events.Message{Topic: beacon.TopicHead, Data: 3})
+
+	msg, ok := <-messages
+	require.True(t, ok)
+	assert.Equal(t, 1, msg.Data)
+
+	_, ok = <-messages
+	assert.False(t, ok)
+}
+
+func TestServiceCallsHooks(t *testing.T) {
+	t.Parallel()
+
+	source := &mockSource{events: make(chan beacon.Event)}
+	svc := events.NewService(source, mockRewards{})
+
+	var reorgs, heads []uint64
+
+	detector := beacon.NewReorgDetector()
+	detector.OnReorg(func(fromSlot uint64) { reorgs = append(reorgs, fromSlot) })
+	svc.OnEvent(detector.HandleEvent)
+	svc.OnEvent(func(event beacon.Event) {
+		if head, ok := event.(*beacon.HeadEvent); ok {
+			heads = append(heads, head.Slot)
+		}
+	})
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		svc.Run(context.Background())
+	}()
+
+	// Hooks get every event, whether or not clients are subscribed.
+	source.events <- &beacon.HeadEvent{Slot: 100}
+	source.events <- &beacon.ChainReorgEvent{Slot: 101, Depth: 2}
+	source.events <- &beacon.HeadEvent{Slot: 101}
+	close(source.events)
+
+	<-done
+
+	assert.Equal(t, []uint64{100}, reorgs)
+	assert.Equal(t, []uint64{100, 101}, heads)
+}
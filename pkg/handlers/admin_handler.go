@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/builders"
+	"github.com/powerslider/ethereum-validator-api/pkg/cache"
+	"github.com/powerslider/ethereum-validator-api/pkg/indexer"
+)
+
+// BeaconNodeStatusService defines a minimal interface for inspecting upstream beacon nodes.
+type BeaconNodeStatusService interface {
+	NodeStatuses() []beacon.NodeStatus
+}
+
+// CacheStatsService defines a minimal interface for inspecting the beacon response cache.
+type CacheStatsService interface {
+	Stats() cache.Stats
+}
+
+// IndexerStatusService defines a minimal interface for inspecting the background indexer.
+type IndexerStatusService interface {
+	Status() indexer.Status
+}
+
+// BuilderRegistryService defines a minimal interface for managing the builder registry.
+type BuilderRegistryService interface {
+	Builders() []builders.Builder
+	Reload() error
+}
+
+// beaconNodesResponse defines the structure returned for beacon node status lookup.
+type beaconNodesResponse struct {
+	Nodes []beacon.NodeStatus `json:"nodes"`
+}
+
+// buildersResponse defines the structure returned for builder registry lookup.
+type buildersResponse struct {
+	Builders []builders.Builder `json:"builders"`
+}
+
+// GetBeaconNodesHandler handles upstream beacon node status lookup.
+// @Summary Get Beacon Node Status
+// @Description Lists the configured beacon nodes with their last known health and sync status, most preferred first.
+// @Description The node marked as active is the one requests are currently routed to.
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} beaconNodesResponse
+// @Failure 401 {object} APIError
+// @Router /admin/beacon/nodes [get]
+func GetBeaconNodesHandler(svc BeaconNodeStatusService) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		resp := beaconNodesResponse{
+			Nodes: svc.NodeStatuses(),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// GetCacheStatsHandler handles beacon response cache statistics lookup.
+// @Summary Get Cache Statistics
+// @Description Reports the size, capacity and hit, miss, eviction and expiry counters of the beacon response cache.
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} cache.Stats
+// @Failure 401 {object} APIError
+// @Router /admin/cache [get]
+func GetCacheStatsHandler(svc CacheStatsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(svc.Stats()); err != nil {
+			return
+		}
+	}
+}
+
+// GetIndexerStatusHandler handles background indexer progress lookup.
+// @Summary Get Indexer Status
+// @Description Reports the last slot indexed by the background indexer, the chain head and how far behind it is.
+// @Description Only available when the indexer is enabled.
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} indexer.Status
+// @Failure 401 {object} APIError
+// @Router /admin/indexer [get]
+func GetIndexerStatusHandler(svc IndexerStatusService) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(svc.Status()); err != nil {
+			return
+		}
+	}
+}
+
+// GetBuildersHandler handles builder registry lookup.
+// @Summary Get Builder Registry
+// @Description Lists the known block builders used to attribute MEV blocks.
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} buildersResponse
+// @Failure 401 {object} APIError
+// @Router /admin/builders [get]
+func GetBuildersHandler(svc BuilderRegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		writeBuilders(w, svc)
+	}
+}
+
+// ReloadBuildersHandler handles builder registry reloads.
+// @Summary Reload Builder Registry
+// @Description Re-reads the builder registry file. On failure the previously loaded builders are kept.
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} buildersResponse
+// @Failure 401 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /admin/builders/reload [post]
+func ReloadBuildersHandler(svc BuilderRegistryService) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if err := svc.Reload(); err != nil {
+			writeAPIError(w, http.StatusInternalServerError, "Failed to reload builder registry", err)
+			return
+		}
+
+		writeBuilders(w, svc)
+	}
+}
+
+// AdminTokenMiddleware rejects requests without the admin token, sent as
+// "Authorization: Bearer <token>", with 401 Unauthorized.
+func AdminTokenMiddleware(token string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sent, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeAPIError(w, http.StatusUnauthorized, "Invalid or missing admin token", nil)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeBuilders(w http.ResponseWriter, svc BuilderRegistryService) {
+	resp := buildersResponse{
+		Builders: svc.Builders(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		return
+	}
+}
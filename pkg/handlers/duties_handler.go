@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/attesterduties"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/proposerduties"
+)
+
+// ProposerDutyService defines a minimal interface for proposer duties operations.
+type ProposerDutyService interface {
+	GetProposerDuties(ctx context.Context, epoch uint64, validators []beacon.ValidatorID) (*proposerduties.Duties, error)
+}
+
+// AttesterDutyService defines a minimal interface for attester duties operations.
+type AttesterDutyService interface {
+	GetAttesterDuties(ctx context.Context, epoch uint64, validators []string) (*attesterduties.Duties, error)
+}
+
+// proposerDutiesResponse defines the structure returned for proposer duties lookup.
+type proposerDutiesResponse struct {
+	DependentRoot string                 `json:"dependent_root"`
+	Proposers     []proposerDutyResponse `json:"proposers"`
+	Epoch         uint64                 `json:"epoch"`
+}
+
+// proposerDutyResponse is the validator scheduled to propose the block of a slot.
+type proposerDutyResponse struct {
+	ValidatorIndex string `json:"validator_index"`
+	Pubkey         string `json:"pubkey"`
+	Slot           uint64 `json:"slot"`
+}
+
+// attesterDutiesResponse defines the structure returned for attester duties lookup.
+type attesterDutiesResponse struct {
+	Committees []attestationCommitteeResponse `json:"committees"`
+	Epoch      uint64                         `json:"epoch"`
+	// Finalized is false while a reorg can still reshuffle the committees.
+	Finalized bool `json:"finalized"`
+}
+
+// attestationCommitteeResponse is an attestation committee of an epoch.
+type attestationCommitteeResponse struct {
+	Members []attestationCommitteeMemberResponse `json:"members"`
+	Index   uint64                               `json:"index"`
+	Slot    uint64                               `json:"slot"`
+}
+
+// attestationCommitteeMemberResponse is a validator of an attestation committee and its position.
+type attestationCommitteeMemberResponse struct {
+	ValidatorIndex string `json:"validator_index"`
+	// Pubkey is only set for requests filtered by validators.
+	Pubkey   string `json:"pubkey,omitempty"`
+	Position int    `json:"position"`
+}
+
+// GetProposerDutiesHandler handles proposer duties lookup.
+// @Summary Get Proposer Duties
+// @Description Retrieves the validators scheduled to propose the blocks of an epoch, in slot order, with their
+// @Description validator index and public key. Proposers are known up to the epoch after the current one;
+// @Description later epochs are answered with 400. validators restricts the result to the given comma-separated
+// @Description validator indices or public keys. A reorg replacing dependent_root reshuffles the proposers.
+// @Tags ProposerDuties
+// @Produce json
+// @Param epoch path int true "Epoch number"
+// @Param validators query string false "Comma-separated validator indices or public keys"
+// @Success 200 {object} proposerDutiesResponse
+// @Failure 400 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /proposerduties/{epoch} [get]
+func GetProposerDutiesHandler(svc ProposerDutyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		epoch, err := strconv.ParseUint(mux.Vars(r)["epoch"], 10, 64)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "Invalid epoch number", err)
+			return
+		}
+
+		validators, err := parseValidatorIDs(r.URL.Query().Get("validators"))
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "Invalid validator identifier", err)
+			return
+		}
+
+		duties, err := svc.GetProposerDuties(r.Context(), epoch, validators)
+		if err != nil {
+			wrappedErr := err
+
+			switch e := pkgerrors.Cause(wrappedErr); {
+			case errors.Is(e, proposerduties.ErrEpochBeyondLookahead):
+				writeAPIError(w, http.StatusBadRequest, "Epoch is beyond the proposer lookahead", wrappedErr)
+			default:
+				writeAPIError(w, http.StatusInternalServerError, "Failed to retrieve proposer duties", wrappedErr)
+			}
+
+			return
+		}
+
+		resp := proposerDutiesResponse{
+			Epoch:         duties.Epoch,
+			DependentRoot: duties.DependentRoot,
+			Proposers:     make([]proposerDutyResponse, 0, len(duties.Proposers)),
+		}
+
+		for _, p := range duties.Proposers {
+			resp.Proposers = append(resp.Proposers, proposerDutyResponse{
+				Slot:           p.Slot,
+				ValidatorIndex: p.ValidatorIndex,
+				Pubkey:         p.Pubkey,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err = json.NewEncoder(w).Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// GetAttesterDutiesHandler handles attester duties lookup.
+// @Summary Get Attester Duties
+// @Description Retrieves the attestation committees of an epoch in slot and committee order, with the position
+// @Description and validator index of their members. Committees are known up to the epoch after the current one;
+// @Description later epochs are answered with 400. validators restricts the result to the committees of the given
+// @Description comma-separated validator indices (at most 10000), listing only those validators with their public key.
+// @Tags AttesterDuties
+// @Produce json
+// @Param epoch path int true "Epoch number"
+// @Param validators query string false "Comma-separated validator indices"
+// @Success 200 {object} attesterDutiesResponse
+// @Failure 400 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /attesterduties/{epoch} [get]
+func GetAttesterDutiesHandler(svc AttesterDutyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		epoch, err := strconv.ParseUint(mux.Vars(r)["epoch"], 10, 64)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "Invalid epoch number", err)
+			return
+		}
+
+		var validators []string
+
+		if param := r.URL.Query().Get("validators"); param != "" {
+			for _, index := range strings.Split(param, ",") {
+				index = strings.TrimSpace(index)
+
+				if _, err = strconv.ParseUint(index, 10, 64); err != nil {
+					writeAPIError(w, http.StatusBadRequest, "Invalid validator index", err)
+					return
+				}
+
+				validators = append(validators, index)
+			}
+		}
+
+		duties, err := svc.GetAttesterDuties(r.Context(), epoch, validators)
+		if err != nil {
+			wrappedErr := err
+
+			switch e := pkgerrors.Cause(wrappedErr); {
+			case errors.Is(e, attesterduties.ErrEpochBeyondLookahead):
+				writeAPIError(w, http.StatusBadRequest, "Epoch is beyond the attester lookahead", wrappedErr)
+			case errors.Is(e, attesterduties.ErrTooManyValidators):
+				writeAPIError(w, http.StatusBadRequest, "Too many validators", wrappedErr)
+			default:
+				writeAPIError(w, http.StatusInternalServerError, "Failed to retrieve attester duties", wrappedErr)
+			}
+
+			return
+		}
+
+		resp := attesterDutiesResponse{
+			Epoch:      duties.Epoch,
+			Finalized:  duties.Finalized,
+			Committees: make([]attestationCommitteeResponse, 0, len(duties.Committees)),
+		}
+
+		for _, c := range duties.Committees {
+			committee := attestationCommitteeResponse{
+				Index:   c.Index,
+				Slot:    c.Slot,
+				Members: make([]attestationCommitteeMemberResponse, 0, len(c.Members)),
+			}
+
+			for _, m := range c.Members {
+				committee.Members = append(committee.Members, attestationCommitteeMemberResponse{
+					Position:       m.Position,
+					ValidatorIndex: m.ValidatorIndex,
+					Pubkey:         m.Pubkey,
+				})
+			}
+
+			resp.Committees = append(resp.Committees, committee)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err = json.NewEncoder(w).Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// parseValidatorIDs parses a comma-separated list of validator identifiers. An empty list yields nil.
+func parseValidatorIDs(param string) ([]beacon.ValidatorID, error) {
+	if param == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(param, ",")
+	ids := make([]beacon.ValidatorID, 0, len(parts))
+
+	for _, part := range parts {
+		id, err := beacon.ParseValidatorID(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
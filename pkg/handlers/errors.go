@@ -3,6 +3,8 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+
+	"github.com/powerslider/ethereum-validator-api/pkg/logging"
 )
 
 type APIError struct {
@@ -11,6 +13,7 @@ type APIError struct {
 	Code    int    `json:"code"`
 }
 
+// writeAPIError writes an API error response. The error is also attached to the request log entry.
 func writeAPIError(w http.ResponseWriter, status int, message string, err error) {
 	apiErr := APIError{
 		Code:    status,
@@ -19,6 +22,7 @@ func writeAPIError(w http.ResponseWriter, status int, message string, err error)
 
 	if err != nil {
 		apiErr.Details = err.Error()
+		logging.RecordError(w, err)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/events"
+)
+
+// keepAliveInterval is how often an idle event stream sends a comment, so that proxies keep it open.
+const keepAliveInterval = 15 * time.Second
+
+// EventService defines a minimal interface for subscribing to chain events.
+type EventService interface {
+	Subscribe(topics []string) (<-chan events.Message, func())
+}
+
+// GetEventsHandler handles chain event streaming.
+// @Summary Stream Chain Events
+// @Description Streams chain events as Server-Sent Events. Each event has the topic as its name and JSON data.
+// @Description Topics: head, block, finalized_checkpoint and chain_reorg are relayed from the beacon node;
+// @Description block_reward carries the reward of every new block (same format as /blockreward/{id}) once computed.
+// @Description Clients that fall too far behind are disconnected and should reconnect.
+// @Tags Events
+// @Produce text/event-stream
+// @Param topics query string false "Comma-separated topics (default: all)"
+// @Success 200 {string} string "event stream"
+// @Failure 400 {object} APIError
+// @Router /events [get]
+func GetEventsHandler(svc EventService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		topics := events.Topics
+
+		if param := r.URL.Query().Get("topics"); param != "" {
+			topics = strings.Split(param, ",")
+
+			for _, topic := range topics {
+				if !events.IsTopic(topic) {
+					writeAPIError(w, http.StatusBadRequest, "Invalid topic", pkgerrors.Errorf("unknown topic %q", topic))
+					return
+				}
+			}
+		}
+
+		messages, unsubscribe := svc.Subscribe(topics)
+		defer unsubscribe()
+
+		rc := http.NewResponseController(w)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+
+		keepAlive := time.NewTicker(keepAliveInterval)
+		defer keepAlive.Stop()
+
+		for {
+			var err error
+
+			select {
+			case <-r.Context().Done():
+				return
+			case <-keepAlive.C:
+				_, err = fmt.Fprint(w, ": keep-alive\n\n")
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				err = writeEvent(w, msg)
+			}
+
+			if err == nil {
+				err = rc.Flush()
+			}
+
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeEvent writes msg in the Server-Sent Events format.
+func writeEvent(w http.ResponseWriter, msg events.Message) error {
+	data, err := json.Marshal(msg.Data)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "encode %s event", msg.Topic)
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.Topic, data)
+
+	return pkgerrors.Wrap(err, "write event")
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/powerslider/ethereum-validator-api/pkg/health"
+)
+
+// ReadinessService defines a minimal interface for checking the upstreams the API depends on.
+type ReadinessService interface {
+	Readiness(ctx context.Context) health.Report
+}
+
+// livenessResponse defines the structure returned for liveness checks.
+type livenessResponse struct {
+	Status string `json:"status"`
+}
+
+// GetLivenessHandler reports that the process is up and serving requests. It does not
+// depend on the upstreams, so an unsynced node never gets the process restarted.
+// It is served outside the API prefix, at /healthz.
+func GetLivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(livenessResponse{Status: "ok"}); err != nil {
+			return
+		}
+	}
+}
+
+// GetReadinessHandler reports whether the beacon nodes and the execution client are reachable
+// and synced, with a breakdown per upstream. It responds with 503 when the API is not ready.
+// It is served outside the API prefix, at /readyz.
+func GetReadinessHandler(svc ReadinessService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := svc.Readiness(r.Context())
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if !report.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/powerslider/ethereum-validator-api/pkg/ratelimit"
+)
+
+// RateLimitMiddleware rejects requests over the rate limit of their client and route with
+// 429 Too Many Requests and a Retry-After header.
+func RateLimitMiddleware(limiter *ratelimit.Limiter) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := r.URL.Path
+			if current := mux.CurrentRoute(r); current != nil {
+				if template, err := current.GetPathTemplate(); err == nil {
+					route = template
+				}
+			}
+
+			if delay, err := limiter.Allow(route, limiter.Client(r)); err != nil {
+				w.Header().Set("Retry-After", ratelimit.RetryAfter(delay))
+				writeAPIError(w, http.StatusTooManyRequests, "Rate limit exceeded", err)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
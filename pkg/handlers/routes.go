@@ -2,21 +2,97 @@ package handlers
 
 import (
 	"github.com/gorilla/mux"
+	"github.com/powerslider/ethereum-validator-api/pkg/attesterduties"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
 	"github.com/powerslider/ethereum-validator-api/pkg/blockreward"
+	"github.com/powerslider/ethereum-validator-api/pkg/builders"
+	"github.com/powerslider/ethereum-validator-api/pkg/cache"
+	"github.com/powerslider/ethereum-validator-api/pkg/chaintime"
+	"github.com/powerslider/ethereum-validator-api/pkg/events"
+	"github.com/powerslider/ethereum-validator-api/pkg/health"
+	"github.com/powerslider/ethereum-validator-api/pkg/indexer"
+	"github.com/powerslider/ethereum-validator-api/pkg/logging"
+	"github.com/powerslider/ethereum-validator-api/pkg/metrics"
+	"github.com/powerslider/ethereum-validator-api/pkg/proposerduties"
+	"github.com/powerslider/ethereum-validator-api/pkg/ratelimit"
 	"github.com/powerslider/ethereum-validator-api/pkg/syncduties"
+	"github.com/powerslider/ethereum-validator-api/pkg/tracing"
+	"github.com/powerslider/ethereum-validator-api/pkg/validatorrewards"
 	httpswagger "github.com/swaggo/http-swagger"
 
 	_ "github.com/powerslider/ethereum-validator-api/docs" // generated docs
 )
 
-// SetupRouter configures all routes and returns a mux.Router.
-func SetupRouter(blockRewardSvc *blockreward.Service, syncDutySvc *syncduties.Service) *mux.Router {
+// Services holds the services the routes are served from.
+type Services struct {
+	BlockRewards     *blockreward.Service
+	SyncDuties       *syncduties.Service
+	ProposerDuties   *proposerduties.Service
+	AttesterDuties   *attesterduties.Service
+	Beacon           *beacon.Service
+	ValidatorRewards *validatorrewards.Service
+	Cache            *cache.BeaconService
+	Builders         *builders.Registry
+	Clock            *chaintime.Clock
+	Health           *health.Service
+	// Indexer is optional; its status is only served when it is set.
+	Indexer *indexer.Service
+	// Events is optional; the event stream is only served when it is set.
+	Events *events.Service
+	// RateLimiter is optional; without it the API is not rate limited.
+	RateLimiter *ratelimit.Limiter
+}
+
+// SetupRouter configures all routes and returns a mux.Router. The admin endpoints are only
+// served when adminToken is set.
+func SetupRouter(svc Services, adminToken string) *mux.Router {
 	r := mux.NewRouter()
+	// Logging is innermost so handlers can record the error and slot of a request on its log entry.
+	r.Use(tracing.Middleware, metrics.Middleware, logging.Middleware)
 
 	// API v1 subrouter
 	apiV1 := r.PathPrefix("/api/v1").Subrouter()
-	apiV1.HandleFunc("/blockreward/{slot:[0-9]+}", GetBlockRewardHandler(blockRewardSvc)).Methods("GET")
-	apiV1.HandleFunc("/syncduties/{slot:[0-9]+}", GetSyncDutiesHandler(syncDutySvc)).Methods("GET")
+	// Rate limiting is optional and only covers the API, not the probes or docs;
+	// rejected requests are still traced, counted and logged.
+	if svc.RateLimiter != nil {
+		apiV1.Use(RateLimitMiddleware(svc.RateLimiter))
+	}
+
+	apiV1.HandleFunc("/blockreward", GetBlockRewardRangeHandler(svc.BlockRewards)).Methods("GET")
+	apiV1.HandleFunc("/blockreward/{id}", GetBlockRewardHandler(svc.BlockRewards)).Methods("GET")
+	apiV1.HandleFunc("/syncduties/{id}", GetSyncDutiesHandler(svc.SyncDuties)).Methods("GET")
+	apiV1.HandleFunc("/proposerduties/{epoch}", GetProposerDutiesHandler(svc.ProposerDuties)).Methods("GET")
+	apiV1.HandleFunc("/attesterduties/{epoch}", GetAttesterDutiesHandler(svc.AttesterDuties)).Methods("GET")
+	apiV1.HandleFunc("/validators/{id}", GetValidatorHandler(svc.Beacon)).Methods("GET")
+	apiV1.HandleFunc("/validators/{id}/rewards", GetValidatorRewardsHandler(svc.ValidatorRewards)).Methods("GET")
+	apiV1.HandleFunc("/time/slot", GetSlotAtTimeHandler(svc.Clock)).Methods("GET")
+	apiV1.HandleFunc("/time/timestamp", GetSlotTimestampHandler(svc.Clock)).Methods("GET")
+	// The event stream is optional; it is only served when events are relayed.
+	if svc.Events != nil {
+		apiV1.HandleFunc("/events", GetEventsHandler(svc.Events)).Methods("GET")
+	}
+
+	// The admin endpoints expose upstream details and change the server state; they are only
+	// served behind the admin token.
+	if adminToken != "" {
+		admin := apiV1.PathPrefix("/admin").Subrouter()
+		admin.Use(AdminTokenMiddleware(adminToken))
+
+		admin.HandleFunc("/beacon/nodes", GetBeaconNodesHandler(svc.Beacon)).Methods("GET")
+		admin.HandleFunc("/cache", GetCacheStatsHandler(svc.Cache)).Methods("GET")
+		// The indexer is optional; its status is only served when it runs.
+		if svc.Indexer != nil {
+			admin.HandleFunc("/indexer", GetIndexerStatusHandler(svc.Indexer)).Methods("GET")
+		}
+
+		admin.HandleFunc("/builders", GetBuildersHandler(svc.Builders)).Methods("GET")
+		admin.HandleFunc("/builders/reload", ReloadBuildersHandler(svc.Builders)).Methods("POST")
+	}
+
+	// Liveness and readiness probes, outside the API prefix.
+	r.HandleFunc("/healthz", GetLivenessHandler()).Methods("GET")
+	r.HandleFunc("/readyz", GetReadinessHandler(svc.Health)).Methods("GET")
+
 	// Swagger endpoint
 	r.PathPrefix("/swagger/").Handler(httpswagger.WrapHandler)
 
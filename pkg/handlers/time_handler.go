@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/chaintime"
+	"github.com/powerslider/ethereum-validator-api/pkg/logging"
+)
+
+// ChainTimeService defines a minimal interface for slot and time conversions.
+type ChainTimeService interface {
+	SlotAt(t time.Time) (uint64, error)
+	SlotTime(slot uint64) (chaintime.SlotTime, error)
+}
+
+// chainTimeResponse defines the structure returned for slot and time conversions.
+// Timestamp and Time are the start of the slot.
+type chainTimeResponse struct {
+	Time                string `json:"time"`
+	Slot                uint64 `json:"slot"`
+	Epoch               uint64 `json:"epoch"`
+	SyncCommitteePeriod uint64 `json:"sync_committee_period"`
+	Timestamp           int64  `json:"timestamp"`
+}
+
+// GetSlotAtTimeHandler handles timestamp to slot conversion.
+// @Summary Get Slot At Time
+// @Description Converts a Unix timestamp (seconds) to the slot in progress at that time,
+// @Description with its epoch, sync committee period and start time.
+// @Tags Time
+// @Produce json
+// @Param timestamp query int true "Unix timestamp in seconds"
+// @Success 200 {object} chainTimeResponse
+// @Failure 400 {object} APIError
+// @Router /time/slot [get]
+func GetSlotAtTimeHandler(svc ChainTimeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		timestamp, err := strconv.ParseInt(r.URL.Query().Get("timestamp"), 10, 64)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "Invalid timestamp", err)
+			return
+		}
+
+		slot, err := svc.SlotAt(time.Unix(timestamp, 0))
+		if err != nil {
+			writeChainTimeError(w, err)
+			return
+		}
+
+		writeSlotTime(w, svc, slot)
+	}
+}
+
+// GetSlotTimestampHandler handles slot to timestamp conversion.
+// @Summary Get Slot Timestamp
+// @Description Converts a slot to its start time, epoch and sync committee period.
+// @Tags Time
+// @Produce json
+// @Param slot query int true "Slot number"
+// @Success 200 {object} chainTimeResponse
+// @Failure 400 {object} APIError
+// @Router /time/timestamp [get]
+func GetSlotTimestampHandler(svc ChainTimeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		slot, err := strconv.ParseUint(r.URL.Query().Get("slot"), 10, 64)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "Invalid slot number", err)
+			return
+		}
+
+		writeSlotTime(w, svc, slot)
+	}
+}
+
+// writeSlotTime writes the chain time of slot as the response.
+func writeSlotTime(w http.ResponseWriter, svc ChainTimeService, slot uint64) {
+	logging.RecordSlot(w, slot)
+
+	slotTime, err := svc.SlotTime(slot)
+	if err != nil {
+		writeChainTimeError(w, err)
+		return
+	}
+
+	resp := chainTimeResponse{
+		Slot:                slotTime.Slot,
+		Epoch:               slotTime.Epoch,
+		SyncCommitteePeriod: slotTime.SyncCommitteePeriod,
+		Timestamp:           slotTime.Start.Unix(),
+		Time:                slotTime.Start.UTC().Format(time.RFC3339),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		return
+	}
+}
+
+// writeChainTimeError maps a chain time conversion error to its API error.
+func writeChainTimeError(w http.ResponseWriter, wrappedErr error) {
+	switch e := pkgerrors.Cause(wrappedErr); {
+	case errors.Is(e, chaintime.ErrBeforeGenesis):
+		writeAPIError(w, http.StatusBadRequest, "Timestamp is before genesis", wrappedErr)
+	case errors.Is(e, chaintime.ErrSlotOutOfRange):
+		writeAPIError(w, http.StatusBadRequest, "Slot is out of range", wrappedErr)
+	default:
+		writeAPIError(w, http.StatusInternalServerError, "Failed to convert chain time", wrappedErr)
+	}
+}
@@ -7,6 +7,11 @@ import (
 	pkgerrors "github.com/pkg/errors"
 	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
 	"github.com/powerslider/ethereum-validator-api/pkg/blockreward"
+	"github.com/powerslider/ethereum-validator-api/pkg/logging"
+	"github.com/powerslider/ethereum-validator-api/pkg/relay"
+	"github.com/powerslider/ethereum-validator-api/pkg/syncduties"
+	"github.com/powerslider/ethereum-validator-api/pkg/validatorrewards"
+	"math/big"
 	"net/http"
 	"strconv"
 
@@ -15,47 +20,172 @@ import (
 
 // BlockRewardService defines a minimal interface for block reward operations.
 type BlockRewardService interface {
-	GetBlockReward(ctx context.Context, slot uint64) (*blockreward.Result, error)
+	GetBlockReward(ctx context.Context, id beacon.BlockID) (*blockreward.Result, error)
+}
+
+// BlockRewardRangeService defines a minimal interface for block reward range operations.
+type BlockRewardRangeService interface {
+	GetBlockRewards(ctx context.Context, from, to uint64) (*blockreward.RangeResult, error)
 }
 
 // SyncDutyService defines a minimal interface for sync duties operations.
 type SyncDutyService interface {
-	GetSyncDuties(ctx context.Context, slot uint64) ([]string, error)
+	GetSyncDuties(ctx context.Context, id beacon.BlockID) (*syncduties.Duties, error)
+}
+
+// ValidatorService defines a minimal interface for validator lookup.
+type ValidatorService interface {
+	GetValidator(ctx context.Context, state beacon.StateID, id beacon.ValidatorID) (*beacon.ValidatorResponse, error)
+}
+
+// ValidatorRewardsService defines a minimal interface for validator reward history operations.
+type ValidatorRewardsService interface {
+	GetValidatorRewards(ctx context.Context, id beacon.ValidatorID, from, to uint64) (*validatorrewards.Result, error)
 }
 
 // blockRewardResponse defines the structure returned for block reward lookup.
+// Reward is the consensus-layer reward in Gwei and is kept for backwards compatibility.
 type blockRewardResponse struct {
-	Status string `json:"status"`
-	Reward string `json:"reward"`
+	BlockRoot           string   `json:"block_root"`
+	Status              string   `json:"status"`
+	Builder             string   `json:"builder,omitempty"`
+	Reward              string   `json:"reward"`
+	ConsensusRewardGwei string   `json:"consensus_reward_gwei"`
+	ExecutionRewardWei  string   `json:"execution_reward_wei"`
+	PriorityFeesWei     string   `json:"priority_fees_wei"`
+	MEVPaymentWei       string   `json:"mev_payment_wei"`
+	MEVPaymentTx        string   `json:"mev_payment_tx,omitempty"`
+	MEVBidValueWei      string   `json:"mev_bid_value_wei,omitempty"`
+	BuilderPubkey       string   `json:"builder_pubkey,omitempty"`
+	Relays              []string `json:"relays,omitempty"`
+	TotalRewardGwei     string   `json:"total_reward_gwei"`
+	TotalRewardWei      string   `json:"total_reward_wei"`
+	Slot                uint64   `json:"slot"`
+	// Finalized is false while a reorg can still replace the block at BlockRoot.
+	Finalized bool `json:"finalized"`
+	// RelayChecked is false when Status was not confirmed by the relays, because none are
+	// configured or some could not be queried.
+	RelayChecked bool `json:"relay_checked"`
+}
+
+// blockRewardRangeResponse defines the structure returned for block reward range lookup.
+type blockRewardRangeResponse struct {
+	Totals blockRewardTotalsResponse `json:"totals"`
+	Slots  []blockRewardSlotResponse `json:"slots"`
+	From   uint64                    `json:"from"`
+	To     uint64                    `json:"to"`
+}
+
+// blockRewardSlotResponse is a single slot of a block reward range lookup.
+// The block reward fields are omitted for missed slots and slots with an error.
+type blockRewardSlotResponse struct {
+	*blockRewardResponse
+
+	Error  string `json:"error,omitempty"`
+	Slot   uint64 `json:"slot"`
+	Missed bool   `json:"missed"`
+}
+
+// blockRewardTotalsResponse aggregates the rewards of a block reward range lookup.
+type blockRewardTotalsResponse struct {
+	ConsensusRewardGwei string `json:"consensus_reward_gwei"`
+	ExecutionRewardWei  string `json:"execution_reward_wei"`
+	TotalRewardGwei     string `json:"total_reward_gwei"`
+	TotalRewardWei      string `json:"total_reward_wei"`
+	ProposedBlocks      int    `json:"proposed_blocks"`
+	MissedSlots         int    `json:"missed_slots"`
+	MEVBlocks           int    `json:"mev_blocks"`
+}
+
+// newBlockRewardResponse maps a block reward result to its API representation.
+func newBlockRewardResponse(result *blockreward.Result) blockRewardResponse {
+	resp := blockRewardResponse{
+		Slot:                result.Slot,
+		BlockRoot:           result.BlockRoot,
+		Status:              result.Status,
+		Builder:             result.Builder,
+		Reward:              result.Reward,
+		ConsensusRewardGwei: result.Reward,
+		ExecutionRewardWei:  bigIntString(nil),
+		PriorityFeesWei:     bigIntString(nil),
+		MEVPaymentWei:       bigIntString(nil),
+		TotalRewardGwei:     bigIntString(result.TotalGwei),
+		TotalRewardWei:      bigIntString(result.TotalWei),
+		Finalized:           result.Finalized,
+		RelayChecked:        result.RelayChecked,
+	}
+
+	if exec := result.Execution; exec != nil {
+		resp.ExecutionRewardWei = bigIntString(exec.Total)
+		resp.PriorityFeesWei = bigIntString(exec.PriorityFees)
+		resp.MEVPaymentWei = bigIntString(exec.MEVPayment)
+		resp.MEVPaymentTx = exec.MEVPaymentTx
+	}
+
+	if bid := result.Bid; bid != nil {
+		resp.MEVBidValueWei = bigIntString(bid.Value)
+		resp.BuilderPubkey = bid.BuilderPubkey
+		resp.Relays = bid.Relays
+	}
+
+	return resp
+}
+
+// bigIntString formats an optional big integer as a decimal string, defaulting to "0".
+func bigIntString(v *big.Int) string {
+	if v == nil {
+		return "0"
+	}
+
+	return v.String()
 }
 
 // syncDutiesResponse defines the structure returned for sync duties lookup.
+// Validators lists the member public keys in committee order and is kept for backwards compatibility.
 type syncDutiesResponse struct {
-	Validators []string `json:"validators"`
+	BlockRoot  string                        `json:"block_root,omitempty"`
+	Validators []string                      `json:"validators"`
+	Members    []syncCommitteeMemberResponse `json:"members"`
+	Slot       uint64                        `json:"slot"`
+	// Finalized is false while a reorg can still replace the block at BlockRoot.
+	Finalized bool `json:"finalized"`
+}
+
+// syncCommitteeMemberResponse is a single sync committee position.
+type syncCommitteeMemberResponse struct {
+	ValidatorIndex string `json:"validator_index"`
+	Pubkey         string `json:"pubkey"`
+	Position       int    `json:"position"`
 }
 
 // GetBlockRewardHandler handles block reward lookup.
 // @Summary Get Block Reward
-// @Description Retrieves block reward details for a given slot.
+// @Description Retrieves block reward details for a given block (slot number, 0x-prefixed block root,
+// @Description or one of head, genesis, finalized, justified): the consensus-layer reward (Gwei),
+// @Description the execution-layer reward from priority fees or the builder's MEV payment (Wei) and their total.
+// @Description Until finalized, a reorg can replace the block of a slot; block_root identifies the block the reward
+// @Description was computed for. A block root that was reorged out is answered with 409.
 // @Tags BlockReward
 // @Accept json
 // @Produce json
-// @Param slot path int true "Slot number"
+// @Param id path string true "Slot number, block root, or head/genesis/finalized/justified"
 // @Success 200 {object} blockRewardResponse
 // @Failure 400 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 422 {object} APIError
 // @Failure 500 {object} APIError
-// @Router /blockreward/{slot} [get]
+// @Failure 503 {object} APIError
+// @Router /blockreward/{id} [get]
 func GetBlockRewardHandler(svc BlockRewardService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		slotStr := mux.Vars(r)["slot"]
-
-		slot, err := strconv.ParseUint(slotStr, 10, 64)
+		id, err := beacon.ParseBlockID(mux.Vars(r)["id"])
 		if err != nil {
-			writeAPIError(w, http.StatusBadRequest, "Invalid slot number", err)
+			writeAPIError(w, http.StatusBadRequest, "Invalid block identifier", err)
 			return
 		}
 
-		result, err := svc.GetBlockReward(r.Context(), slot)
+		result, err := svc.GetBlockReward(r.Context(), id)
 		if err != nil {
 			wrappedErr := err
 
@@ -64,6 +194,12 @@ func GetBlockRewardHandler(svc BlockRewardService) http.HandlerFunc {
 				writeAPIError(w, http.StatusNotFound, "Slot was missed", wrappedErr)
 			case errors.Is(e, beacon.ErrSlotInFuture):
 				writeAPIError(w, http.StatusBadRequest, "Slot is in the future", wrappedErr)
+			case errors.Is(e, beacon.ErrNoExecutionPayload):
+				writeAPIError(w, http.StatusUnprocessableEntity, "Slot has no execution payload", wrappedErr)
+			case errors.Is(e, beacon.ErrBlockNotCanonical):
+				writeAPIError(w, http.StatusConflict, "Block is not canonical", wrappedErr)
+			case errors.Is(e, relay.ErrRelaysUnavailable):
+				writeAPIError(w, http.StatusServiceUnavailable, "Relays unavailable", wrappedErr)
 			default:
 				writeAPIError(w, http.StatusInternalServerError, "Failed to retrieve block reward", wrappedErr)
 			}
@@ -71,9 +207,96 @@ func GetBlockRewardHandler(svc BlockRewardService) http.HandlerFunc {
 			return
 		}
 
-		resp := blockRewardResponse{
-			Status: result.Status,
-			Reward: result.Reward,
+		logging.RecordSlot(w, result.Slot)
+
+		resp := newBlockRewardResponse(result)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err = json.NewEncoder(w).Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// GetBlockRewardRangeHandler handles block reward lookup over a range of slots.
+// @Summary Get Block Rewards For A Slot Range
+// @Description Retrieves block reward details for every slot in [from, to], ordered by slot, with aggregate totals.
+// @Description Missed slots are reported inline instead of failing the request.
+// @Tags BlockReward
+// @Accept json
+// @Produce json
+// @Param from query int true "First slot (inclusive)"
+// @Param to query int true "Last slot (inclusive)"
+// @Success 200 {object} blockRewardRangeResponse
+// @Failure 400 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 503 {object} APIError
+// @Router /blockreward [get]
+func GetBlockRewardRangeHandler(svc BlockRewardRangeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		from, err := strconv.ParseUint(query.Get("from"), 10, 64)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "Invalid from slot number", err)
+			return
+		}
+
+		to, err := strconv.ParseUint(query.Get("to"), 10, 64)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "Invalid to slot number", err)
+			return
+		}
+
+		result, err := svc.GetBlockRewards(r.Context(), from, to)
+		if err != nil {
+			wrappedErr := err
+
+			switch e := pkgerrors.Cause(wrappedErr); {
+			case errors.Is(e, blockreward.ErrInvalidRange):
+				writeAPIError(w, http.StatusBadRequest, "Invalid slot range", wrappedErr)
+			case errors.Is(e, blockreward.ErrRangeTooLarge):
+				writeAPIError(w, http.StatusBadRequest, "Slot range too large", wrappedErr)
+			case errors.Is(e, beacon.ErrSlotInFuture):
+				writeAPIError(w, http.StatusBadRequest, "Slot is in the future", wrappedErr)
+			case errors.Is(e, relay.ErrRelaysUnavailable):
+				writeAPIError(w, http.StatusServiceUnavailable, "Relays unavailable", wrappedErr)
+			default:
+				writeAPIError(w, http.StatusInternalServerError, "Failed to retrieve block rewards", wrappedErr)
+			}
+
+			return
+		}
+
+		resp := blockRewardRangeResponse{
+			From:  result.From,
+			To:    result.To,
+			Slots: make([]blockRewardSlotResponse, 0, len(result.Slots)),
+			Totals: blockRewardTotalsResponse{
+				ConsensusRewardGwei: bigIntString(result.Totals.ConsensusGwei),
+				ExecutionRewardWei:  bigIntString(result.Totals.ExecutionWei),
+				TotalRewardGwei:     bigIntString(result.Totals.TotalGwei),
+				TotalRewardWei:      bigIntString(result.Totals.TotalWei),
+				ProposedBlocks:      result.Totals.Proposed,
+				MissedSlots:         result.Totals.Missed,
+				MEVBlocks:           result.Totals.MEV,
+			},
+		}
+
+		for _, slot := range result.Slots {
+			entry := blockRewardSlotResponse{
+				Slot:   slot.Slot,
+				Missed: slot.Missed,
+				Error:  slot.Error,
+			}
+
+			if slot.Result != nil {
+				reward := newBlockRewardResponse(slot.Result)
+				entry.blockRewardResponse = &reward
+			}
+
+			resp.Slots = append(resp.Slots, entry)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -86,26 +309,30 @@ func GetBlockRewardHandler(svc BlockRewardService) http.HandlerFunc {
 
 // GetSyncDutiesHandler handles sync committee duties lookup.
 // @Summary Get Sync Duties
-// @Description Retrieves validators assigned for sync committee duties for a given slot.
+// @Description Retrieves validators assigned for sync committee duties for a given block (slot number,
+// @Description 0x-prefixed block root, or one of head, genesis, finalized, justified).
+// @Description Members are listed in committee order with their position, validator index and public key;
+// @Description a validator occupying several positions is listed once per position.
+// @Description Until finalized, block_root identifies the block the duties were resolved for.
+// @Description A block root that was reorged out is answered with 409.
 // @Tags SyncDuties
 // @Accept json
 // @Produce json
-// @Param slot path int true "Slot number"
+// @Param id path string true "Slot number, block root, or head/genesis/finalized/justified"
 // @Success 200 {object} syncDutiesResponse
 // @Failure 400 {object} APIError
+// @Failure 409 {object} APIError
 // @Failure 500 {object} APIError
-// @Router /syncduties/{slot} [get]
+// @Router /syncduties/{id} [get]
 func GetSyncDutiesHandler(svc SyncDutyService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		slotStr := mux.Vars(r)["slot"]
-
-		slot, err := strconv.ParseUint(slotStr, 10, 64)
+		id, err := beacon.ParseBlockID(mux.Vars(r)["id"])
 		if err != nil {
-			writeAPIError(w, http.StatusBadRequest, "Invalid slot number", err)
+			writeAPIError(w, http.StatusBadRequest, "Invalid block identifier", err)
 			return
 		}
 
-		validators, err := svc.GetSyncDuties(r.Context(), slot)
+		duties, err := svc.GetSyncDuties(r.Context(), id)
 		if err != nil {
 			wrappedErr := err
 
@@ -116,6 +343,8 @@ func GetSyncDutiesHandler(svc SyncDutyService) http.HandlerFunc {
 				writeAPIError(w, http.StatusBadRequest, "Slot is in the future", wrappedErr)
 			case errors.Is(e, beacon.ErrSlotWasMissed):
 				writeAPIError(w, http.StatusBadRequest, "Slot was missed", wrappedErr)
+			case errors.Is(e, beacon.ErrBlockNotCanonical):
+				writeAPIError(w, http.StatusConflict, "Block is not canonical", wrappedErr)
 			default:
 				writeAPIError(w, http.StatusInternalServerError, "Failed to retrieve sync duties", wrappedErr)
 			}
@@ -123,8 +352,23 @@ func GetSyncDutiesHandler(svc SyncDutyService) http.HandlerFunc {
 			return
 		}
 
+		logging.RecordSlot(w, duties.Slot)
+
 		resp := syncDutiesResponse{
-			Validators: validators,
+			Slot:       duties.Slot,
+			BlockRoot:  duties.BlockRoot,
+			Finalized:  duties.Finalized,
+			Validators: make([]string, 0, len(duties.Members)),
+			Members:    make([]syncCommitteeMemberResponse, 0, len(duties.Members)),
+		}
+
+		for _, m := range duties.Members {
+			resp.Validators = append(resp.Validators, m.Pubkey)
+			resp.Members = append(resp.Members, syncCommitteeMemberResponse{
+				Position:       m.Position,
+				ValidatorIndex: m.ValidatorIndex,
+				Pubkey:         m.Pubkey,
+			})
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -134,3 +378,266 @@ func GetSyncDutiesHandler(svc SyncDutyService) http.HandlerFunc {
 		}
 	}
 }
+
+// validatorResponse defines the structure returned for validator lookup.
+// Balances are in Gwei. Epochs that are not scheduled yet are null.
+type validatorResponse struct {
+	ActivationEligibilityEpoch *uint64 `json:"activation_eligibility_epoch"`
+	ActivationEpoch            *uint64 `json:"activation_epoch"`
+	ExitEpoch                  *uint64 `json:"exit_epoch"`
+	WithdrawableEpoch          *uint64 `json:"withdrawable_epoch"`
+	Index                      string  `json:"index"`
+	Pubkey                     string  `json:"pubkey"`
+	Status                     string  `json:"status"`
+	BalanceGwei                string  `json:"balance_gwei"`
+	EffectiveBalanceGwei       string  `json:"effective_balance_gwei"`
+	WithdrawalCredentials      string  `json:"withdrawal_credentials"`
+	State                      string  `json:"state"`
+	Slashed                    bool    `json:"slashed"`
+	Finalized                  bool    `json:"finalized"`
+}
+
+// GetValidatorHandler handles validator lookup.
+// @Summary Get Validator
+// @Description Retrieves a validator by index or 0x-prefixed public key: its status, balance and effective balance
+// @Description (Gwei), activation and exit epochs, slashed flag and withdrawal credentials.
+// @Description By default the head state is used; state accepts a slot number, state root,
+// @Description or one of head, genesis, finalized, justified.
+// @Tags Validators
+// @Produce json
+// @Param id path string true "Validator index or public key"
+// @Param state query string false "State identifier (default: head)"
+// @Success 200 {object} validatorResponse
+// @Failure 400 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /validators/{id} [get]
+func GetValidatorHandler(svc ValidatorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := beacon.ParseValidatorID(mux.Vars(r)["id"])
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "Invalid validator identifier", err)
+			return
+		}
+
+		state := beacon.StateID(beacon.BlockHead)
+
+		if param := r.URL.Query().Get("state"); param != "" {
+			if state, err = beacon.ParseStateID(param); err != nil {
+				writeAPIError(w, http.StatusBadRequest, "Invalid state identifier", err)
+				return
+			}
+		}
+
+		validator, err := svc.GetValidator(r.Context(), state, id)
+		if err != nil {
+			wrappedErr := err
+
+			switch e := pkgerrors.Cause(wrappedErr); {
+			case errors.Is(e, beacon.ErrStateNotFound):
+				writeAPIError(w, http.StatusNotFound, "State not found", wrappedErr)
+			case errors.Is(e, beacon.ErrValidatorNotFound):
+				writeAPIError(w, http.StatusNotFound, "Validator not found", wrappedErr)
+			default:
+				writeAPIError(w, http.StatusInternalServerError, "Failed to retrieve validator", wrappedErr)
+			}
+
+			return
+		}
+
+		entry := validator.Data
+		resp := validatorResponse{
+			Index:                      entry.Index,
+			Pubkey:                     entry.Validator.Pubkey,
+			Status:                     entry.Status,
+			BalanceGwei:                uint64String(entry.Balance),
+			EffectiveBalanceGwei:       uint64String(entry.Validator.EffectiveBalance),
+			ActivationEligibilityEpoch: scheduledEpoch(entry.Validator.ActivationEligibilityEpoch),
+			ActivationEpoch:            scheduledEpoch(entry.Validator.ActivationEpoch),
+			ExitEpoch:                  scheduledEpoch(entry.Validator.ExitEpoch),
+			WithdrawableEpoch:          scheduledEpoch(entry.Validator.WithdrawableEpoch),
+			WithdrawalCredentials:      entry.Validator.WithdrawalCredentials,
+			Slashed:                    entry.Validator.Slashed,
+			State:                      string(state),
+			Finalized:                  validator.Finalized,
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err = json.NewEncoder(w).Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// validatorRewardsResponse defines the structure returned for validator reward history lookup.
+type validatorRewardsResponse struct {
+	ValidatorIndex string                          `json:"validator_index"`
+	Epochs         []validatorEpochRewardsResponse `json:"epochs"`
+	Totals         rewardBreakdownResponse         `json:"totals"`
+	FromEpoch      uint64                          `json:"from_epoch"`
+	ToEpoch        uint64                          `json:"to_epoch"`
+}
+
+// validatorEpochRewardsResponse is a single epoch of a validator reward history lookup.
+type validatorEpochRewardsResponse struct {
+	Proposals     []proposalRewardResponse `json:"proposals"`
+	Rewards       rewardBreakdownResponse  `json:"rewards"`
+	Epoch         uint64                   `json:"epoch"`
+	SyncCommittee bool                     `json:"sync_committee"`
+}
+
+// rewardBreakdownResponse breaks validator rewards down by source, in Gwei. Penalties are negative.
+type rewardBreakdownResponse struct {
+	AttestationHeadGwei           string `json:"attestation_head_gwei"`
+	AttestationTargetGwei         string `json:"attestation_target_gwei"`
+	AttestationSourceGwei         string `json:"attestation_source_gwei"`
+	AttestationInclusionDelayGwei string `json:"attestation_inclusion_delay_gwei"`
+	AttestationInactivityGwei     string `json:"attestation_inactivity_gwei"`
+	SyncCommitteeGwei             string `json:"sync_committee_gwei"`
+	ProposalConsensusGwei         string `json:"proposal_consensus_gwei"`
+	ProposalExecutionGwei         string `json:"proposal_execution_gwei"`
+	TotalGwei                     string `json:"total_gwei"`
+}
+
+// proposalRewardResponse is a block the validator was scheduled to propose.
+type proposalRewardResponse struct {
+	BlockRoot     string `json:"block_root,omitempty"`
+	ConsensusGwei string `json:"consensus_reward_gwei"`
+	ExecutionGwei string `json:"execution_reward_gwei"`
+	Slot          uint64 `json:"slot"`
+	Missed        bool   `json:"missed"`
+}
+
+// GetValidatorRewardsHandler handles validator reward history lookup.
+// @Summary Get Validator Rewards
+// @Description Retrieves the rewards a validator (index or 0x-prefixed public key) earned in every epoch
+// @Description of [from_epoch, to_epoch], ordered by epoch, with totals. Rewards are broken down into
+// @Description attestation head, target, source, inclusion delay and inactivity, sync committee and block
+// @Description proposal (consensus and execution layer) rewards, in Gwei; penalties are negative.
+// @Description Proposals list every slot the validator was scheduled to propose, missed ones included.
+// @Description An epoch can be requested once the epoch after it is over.
+// @Tags Validators
+// @Produce json
+// @Param id path string true "Validator index or public key"
+// @Param from_epoch query int true "First epoch (inclusive)"
+// @Param to_epoch query int true "Last epoch (inclusive)"
+// @Success 200 {object} validatorRewardsResponse
+// @Failure 400 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /validators/{id}/rewards [get]
+func GetValidatorRewardsHandler(svc ValidatorRewardsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := beacon.ParseValidatorID(mux.Vars(r)["id"])
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "Invalid validator identifier", err)
+			return
+		}
+
+		query := r.URL.Query()
+
+		from, err := strconv.ParseUint(query.Get("from_epoch"), 10, 64)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "Invalid from epoch number", err)
+			return
+		}
+
+		to, err := strconv.ParseUint(query.Get("to_epoch"), 10, 64)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "Invalid to epoch number", err)
+			return
+		}
+
+		result, err := svc.GetValidatorRewards(r.Context(), id, from, to)
+		if err != nil {
+			wrappedErr := err
+
+			switch e := pkgerrors.Cause(wrappedErr); {
+			case errors.Is(e, validatorrewards.ErrInvalidRange):
+				writeAPIError(w, http.StatusBadRequest, "Invalid epoch range", wrappedErr)
+			case errors.Is(e, validatorrewards.ErrRangeTooLarge):
+				writeAPIError(w, http.StatusBadRequest, "Epoch range too large", wrappedErr)
+			case errors.Is(e, validatorrewards.ErrEpochNotFinished):
+				writeAPIError(w, http.StatusBadRequest, "Epoch rewards are not known yet", wrappedErr)
+			case errors.Is(e, beacon.ErrStateNotFound):
+				writeAPIError(w, http.StatusNotFound, "State not found", wrappedErr)
+			case errors.Is(e, beacon.ErrValidatorNotFound):
+				writeAPIError(w, http.StatusNotFound, "Validator not found", wrappedErr)
+			default:
+				writeAPIError(w, http.StatusInternalServerError, "Failed to retrieve validator rewards", wrappedErr)
+			}
+
+			return
+		}
+
+		resp := validatorRewardsResponse{
+			ValidatorIndex: result.ValidatorIndex,
+			FromEpoch:      result.FromEpoch,
+			ToEpoch:        result.ToEpoch,
+			Epochs:         make([]validatorEpochRewardsResponse, 0, len(result.Epochs)),
+			Totals:         newRewardBreakdownResponse(result.Totals),
+		}
+
+		for _, epoch := range result.Epochs {
+			entry := validatorEpochRewardsResponse{
+				Epoch:         epoch.Epoch,
+				SyncCommittee: epoch.SyncCommittee,
+				Rewards:       newRewardBreakdownResponse(epoch.Breakdown),
+				Proposals:     make([]proposalRewardResponse, 0, len(epoch.Proposals)),
+			}
+
+			for _, proposal := range epoch.Proposals {
+				entry.Proposals = append(entry.Proposals, proposalRewardResponse{
+					Slot:          proposal.Slot,
+					BlockRoot:     proposal.BlockRoot,
+					Missed:        proposal.Missed,
+					ConsensusGwei: int64String(proposal.ConsensusGwei),
+					ExecutionGwei: int64String(proposal.ExecutionGwei),
+				})
+			}
+
+			resp.Epochs = append(resp.Epochs, entry)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err = json.NewEncoder(w).Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// newRewardBreakdownResponse maps a reward breakdown to its API representation.
+func newRewardBreakdownResponse(b validatorrewards.Breakdown) rewardBreakdownResponse {
+	return rewardBreakdownResponse{
+		AttestationHeadGwei:           int64String(b.AttestationHead),
+		AttestationTargetGwei:         int64String(b.AttestationTarget),
+		AttestationSourceGwei:         int64String(b.AttestationSource),
+		AttestationInclusionDelayGwei: int64String(b.AttestationInclusionDelay),
+		AttestationInactivityGwei:     int64String(b.AttestationInactivity),
+		SyncCommitteeGwei:             int64String(b.SyncCommittee),
+		ProposalConsensusGwei:         int64String(b.ProposalConsensus),
+		ProposalExecutionGwei:         int64String(b.ProposalExecution),
+		TotalGwei:                     int64String(b.Total),
+	}
+}
+
+// scheduledEpoch returns nil for an epoch that is not scheduled yet.
+func scheduledEpoch(epoch uint64) *uint64 {
+	if epoch == beacon.FarFutureEpoch {
+		return nil
+	}
+
+	return &epoch
+}
+
+// uint64String formats an amount as a decimal string, the way amounts are returned elsewhere.
+func uint64String(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
+
+// int64String formats a signed amount as a decimal string.
+func int64String(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
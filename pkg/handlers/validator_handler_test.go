@@ -3,28 +3,73 @@ package handlers_test
 import (
 	"context"
 	"errors"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/attesterduties"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
 	"github.com/powerslider/ethereum-validator-api/pkg/blockreward"
+	"github.com/powerslider/ethereum-validator-api/pkg/builders"
+	"github.com/powerslider/ethereum-validator-api/pkg/chaintime"
 	"github.com/powerslider/ethereum-validator-api/pkg/handlers"
+	"github.com/powerslider/ethereum-validator-api/pkg/health"
+	"github.com/powerslider/ethereum-validator-api/pkg/proposerduties"
+	"github.com/powerslider/ethereum-validator-api/pkg/ratelimit"
+	"github.com/powerslider/ethereum-validator-api/pkg/relay"
+	"github.com/powerslider/ethereum-validator-api/pkg/syncduties"
+	"github.com/powerslider/ethereum-validator-api/pkg/validatorrewards"
 	"github.com/stretchr/testify/require"
 )
 
 type mockBlockRewardService struct {
+	err         error
 	returnError bool
 }
 
-func (m *mockBlockRewardService) GetBlockReward(ctx context.Context, slot uint64) (*blockreward.Result, error) {
+func (m *mockBlockRewardService) GetBlockReward(ctx context.Context, id beacon.BlockID) (*blockreward.Result, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
 	if m.returnError {
 		return nil, errors.New("block reward service error")
 	}
 
 	return &blockreward.Result{
-		Status: "vanilla",
-		Reward: "1000",
+		Slot:      123456,
+		BlockRoot: "0xroot",
+		Status:    "vanilla",
+		Reward:    "1000",
+		Execution: &blockreward.ExecutionReward{
+			PriorityFees: big.NewInt(2_000_000_000),
+			MEVPayment:   new(big.Int),
+			Total:        big.NewInt(2_000_000_000),
+		},
+		TotalWei:  big.NewInt(1_002_000_000_000),
+		TotalGwei: big.NewInt(1_002),
+	}, nil
+}
+
+type mockBlockRewardRangeService struct{}
+
+func (m *mockBlockRewardRangeService) GetBlockRewards(ctx context.Context, from, to uint64) (*blockreward.RangeResult, error) {
+	if to-from+1 > 10 {
+		return nil, pkgerrors.Wrap(blockreward.ErrRangeTooLarge, "11 slots requested")
+	}
+
+	return &blockreward.RangeResult{
+		From: from,
+		To:   to,
+		Slots: []blockreward.SlotResult{
+			{Slot: from, Result: &blockreward.Result{Status: "mev", Reward: "1000"}},
+			{Slot: to, Missed: true},
+		},
+		Totals: blockreward.RangeTotals{Proposed: 1, Missed: 1, MEV: 1},
 	}, nil
 }
 
@@ -32,12 +77,160 @@ type mockSyncDutyService struct {
 	returnError bool
 }
 
-func (m *mockSyncDutyService) GetSyncDuties(ctx context.Context, slot uint64) ([]string, error) {
+func (m *mockSyncDutyService) GetSyncDuties(ctx context.Context, id beacon.BlockID) (*syncduties.Duties, error) {
 	if m.returnError {
 		return nil, errors.New("sync duty service error")
 	}
 
-	return []string{"0xabc", "0xdef"}, nil
+	return &syncduties.Duties{
+		Slot:      123456,
+		BlockRoot: "0xroot",
+		Members: []syncduties.Member{
+			{Position: 0, ValidatorIndex: "1", Pubkey: "0xabc"},
+			{Position: 1, ValidatorIndex: "2", Pubkey: "0xdef"},
+			{Position: 2, ValidatorIndex: "1", Pubkey: "0xabc"},
+		},
+	}, nil
+}
+
+type mockValidatorService struct{}
+
+func (m *mockValidatorService) GetValidator(
+	ctx context.Context,
+	state beacon.StateID,
+	id beacon.ValidatorID,
+) (*beacon.ValidatorResponse, error) {
+	if state == beacon.SlotStateID(99999999) {
+		return nil, pkgerrors.Wrapf(beacon.ErrStateNotFound, "state %s", state)
+	}
+
+	if id != "42" {
+		return nil, pkgerrors.Wrapf(beacon.ErrValidatorNotFound, "validator %s", id)
+	}
+
+	return &beacon.ValidatorResponse{
+		Data: beacon.ValidatorEntry{
+			Index:   "42",
+			Status:  "active_ongoing",
+			Balance: 32_001_000_000,
+			Validator: beacon.ValidatorInfo{
+				Pubkey:            "0xabc",
+				EffectiveBalance:  32_000_000_000,
+				ActivationEpoch:   10,
+				ExitEpoch:         beacon.FarFutureEpoch,
+				WithdrawableEpoch: beacon.FarFutureEpoch,
+			},
+		},
+	}, nil
+}
+
+type mockProposerDutyService struct{}
+
+func (m *mockProposerDutyService) GetProposerDuties(
+	ctx context.Context,
+	epoch uint64,
+	validators []beacon.ValidatorID,
+) (*proposerduties.Duties, error) {
+	if epoch > 100 {
+		return nil, pkgerrors.Wrapf(proposerduties.ErrEpochBeyondLookahead, "epoch %d", epoch)
+	}
+
+	return &proposerduties.Duties{
+		Epoch:         epoch,
+		DependentRoot: "0xdependent",
+		Proposers:     []proposerduties.Proposer{{Slot: epoch * 32, ValidatorIndex: "42", Pubkey: "0xabc"}},
+	}, nil
+}
+
+type mockAttesterDutyService struct{}
+
+func (m *mockAttesterDutyService) GetAttesterDuties(
+	ctx context.Context,
+	epoch uint64,
+	validators []string,
+) (*attesterduties.Duties, error) {
+	if len(validators) > 2 {
+		return nil, pkgerrors.Wrap(attesterduties.ErrTooManyValidators, "3 validators, at most 2 allowed")
+	}
+
+	if len(validators) == 0 {
+		return &attesterduties.Duties{
+			Epoch: epoch,
+			Committees: []attesterduties.Committee{{
+				Index:   3,
+				Slot:    epoch * 32,
+				Members: []attesterduties.Member{{ValidatorIndex: "42", Position: 7}},
+			}},
+		}, nil
+	}
+
+	return &attesterduties.Duties{
+		Epoch: epoch,
+		Committees: []attesterduties.Committee{{
+			Index:   3,
+			Slot:    epoch * 32,
+			Members: []attesterduties.Member{{ValidatorIndex: validators[0], Pubkey: "0xabc", Position: 7}},
+		}},
+	}, nil
+}
+
+type mockValidatorRewardsService struct{}
+
+func (m *mockValidatorRewardsService) GetValidatorRewards(
+	ctx context.Context,
+	id beacon.ValidatorID,
+	from, to uint64,
+) (*validatorrewards.Result, error) {
+	if id != "42" {
+		return nil, pkgerrors.Wrapf(beacon.ErrValidatorNotFound, "validator %s", id)
+	}
+
+	if to-from >= 10 {
+		return nil, pkgerrors.Wrap(validatorrewards.ErrRangeTooLarge, "11 epochs requested")
+	}
+
+	breakdown := validatorrewards.Breakdown{AttestationHead: 10, AttestationInactivity: -1, Total: 9}
+
+	return &validatorrewards.Result{
+		ValidatorIndex: "42",
+		FromEpoch:      from,
+		ToEpoch:        to,
+		Epochs: []validatorrewards.EpochRewards{
+			{Epoch: from, Breakdown: breakdown, Proposals: []validatorrewards.Proposal{{Slot: from * 32, Missed: true}}},
+		},
+		Totals: breakdown,
+	}, nil
+}
+
+type mockReadinessService struct {
+	ready bool
+}
+
+func (m *mockReadinessService) Readiness(ctx context.Context) health.Report {
+	return health.Report{
+		Beacon:    health.BeaconReport{Ready: true},
+		Execution: health.ExecutionReport{Ready: m.ready, Reachable: true, IsSyncing: !m.ready},
+		Ready:     m.ready,
+	}
+}
+
+// testClock uses the mainnet genesis time and spec.
+var testClock = chaintime.NewClock(time.Unix(1606824023, 0), 12, 32, 256)
+
+type mockBuilderRegistry struct {
+	returnError bool
+}
+
+func (m *mockBuilderRegistry) Builders() []builders.Builder {
+	return []builders.Builder{{Name: "Titan"}}
+}
+
+func (m *mockBuilderRegistry) Reload() error {
+	if m.returnError {
+		return errors.New("parse builder registry")
+	}
+
+	return nil
 }
 
 func TestHandlers(t *testing.T) {
@@ -49,76 +242,451 @@ func TestHandlers(t *testing.T) {
 	}
 
 	type testCase struct {
-		route      routeSetup
-		name       string
-		url        string
-		expectBody string
-		expected   int
+		route         routeSetup
+		headers       map[string]string
+		expectHeaders map[string]string
+		name          string
+		url           string
+		expectBody    string
+		expected      int
 	}
 
+	// The rate limited client has used up its only request; httptest requests come from 192.0.2.1.
+	limiter := ratelimit.NewLimiter(ratelimit.Limit{RPS: 0.1, Burst: 1}, nil, 0)
+	_, err := limiter.Allow("/time/timestamp", "ip:192.0.2.1")
+	require.NoError(t, err)
+
 	testCases := []testCase{
 		// BlockReward tests
 		{
 			name: "BlockReward BadRequest",
 			route: routeSetup{
-				path:    "/blockreward/{slot}",
+				path:    "/blockreward/{id}",
 				handler: handlers.GetBlockRewardHandler(&mockBlockRewardService{}),
 			},
 			url:        "/blockreward/not-a-number",
 			expected:   http.StatusBadRequest,
-			expectBody: "Invalid slot number",
+			expectBody: "Invalid block identifier",
+		},
+		{
+			name: "BlockReward Success Named Block",
+			route: routeSetup{
+				path:    "/blockreward/{id}",
+				handler: handlers.GetBlockRewardHandler(&mockBlockRewardService{}),
+			},
+			url:        "/blockreward/head",
+			expected:   http.StatusOK,
+			expectBody: `"slot":123456`,
 		},
 		{
 			name: "BlockReward Success",
 			route: routeSetup{
-				path:    "/blockreward/{slot}",
+				path:    "/blockreward/{id}",
 				handler: handlers.GetBlockRewardHandler(&mockBlockRewardService{}),
 			},
 			url:        "/blockreward/123456",
 			expected:   http.StatusOK,
 			expectBody: "vanilla",
 		},
+		{
+			name: "BlockReward Success With Execution Reward",
+			route: routeSetup{
+				path:    "/blockreward/{id}",
+				handler: handlers.GetBlockRewardHandler(&mockBlockRewardService{}),
+			},
+			url:        "/blockreward/123456",
+			expected:   http.StatusOK,
+			expectBody: `"total_reward_wei":"1002000000000"`,
+		},
 		{
 			name: "BlockReward InternalServerError",
 			route: routeSetup{
-				path:    "/blockreward/{slot}",
+				path:    "/blockreward/{id}",
 				handler: handlers.GetBlockRewardHandler(&mockBlockRewardService{returnError: true}),
 			},
 			url:        "/blockreward/123456",
 			expected:   http.StatusInternalServerError,
 			expectBody: "Failed to retrieve block reward",
 		},
+		{
+			name: "BlockReward UnprocessableEntity",
+			route: routeSetup{
+				path: "/blockreward/{id}",
+				handler: handlers.GetBlockRewardHandler(&mockBlockRewardService{
+					err: pkgerrors.Wrap(beacon.ErrNoExecutionPayload, "fetch execution payload"),
+				}),
+			},
+			url:        "/blockreward/1000",
+			expected:   http.StatusUnprocessableEntity,
+			expectBody: "Slot has no execution payload",
+		},
+		{
+			name: "BlockReward ServiceUnavailable",
+			route: routeSetup{
+				path: "/blockreward/{id}",
+				handler: handlers.GetBlockRewardHandler(&mockBlockRewardService{
+					err: pkgerrors.Wrap(relay.ErrRelaysUnavailable, "fetch relay bid"),
+				}),
+			},
+			url:        "/blockreward/1000",
+			expected:   http.StatusServiceUnavailable,
+			expectBody: "Relays unavailable",
+		},
+		// BlockReward range tests
+		{
+			name: "BlockRewardRange BadRequest",
+			route: routeSetup{
+				path:    "/blockreward",
+				handler: handlers.GetBlockRewardRangeHandler(&mockBlockRewardRangeService{}),
+			},
+			url:        "/blockreward?from=abc&to=10",
+			expected:   http.StatusBadRequest,
+			expectBody: "Invalid from slot number",
+		},
+		{
+			name: "BlockRewardRange TooLarge",
+			route: routeSetup{
+				path:    "/blockreward",
+				handler: handlers.GetBlockRewardRangeHandler(&mockBlockRewardRangeService{}),
+			},
+			url:        "/blockreward?from=0&to=10",
+			expected:   http.StatusBadRequest,
+			expectBody: "Slot range too large",
+		},
+		{
+			name: "BlockRewardRange Success",
+			route: routeSetup{
+				path:    "/blockreward",
+				handler: handlers.GetBlockRewardRangeHandler(&mockBlockRewardRangeService{}),
+			},
+			url:        "/blockreward?from=100&to=101",
+			expected:   http.StatusOK,
+			expectBody: `{"slot":101,"missed":true}`,
+		},
+		// Time conversion tests
+		{
+			name: "SlotAtTime Success",
+			route: routeSetup{
+				path:    "/time/slot",
+				handler: handlers.GetSlotAtTimeHandler(testClock),
+			},
+			url:        "/time/slot?timestamp=1606825235",
+			expected:   http.StatusOK,
+			expectBody: `"slot":101,"epoch":3`,
+		},
+		{
+			name: "SlotAtTime BeforeGenesis",
+			route: routeSetup{
+				path:    "/time/slot",
+				handler: handlers.GetSlotAtTimeHandler(testClock),
+			},
+			url:        "/time/slot?timestamp=1000",
+			expected:   http.StatusBadRequest,
+			expectBody: "Timestamp is before genesis",
+		},
+		{
+			name: "SlotTimestamp Success",
+			route: routeSetup{
+				path:    "/time/timestamp",
+				handler: handlers.GetSlotTimestampHandler(testClock),
+			},
+			url:        "/time/timestamp?slot=101",
+			expected:   http.StatusOK,
+			expectBody: `"timestamp":1606825235`,
+		},
+		// Validator tests
+		{
+			name: "Validator BadRequest",
+			route: routeSetup{
+				path:    "/validators/{id}",
+				handler: handlers.GetValidatorHandler(&mockValidatorService{}),
+			},
+			url:        "/validators/0x1234",
+			expected:   http.StatusBadRequest,
+			expectBody: "Invalid validator identifier",
+		},
+		{
+			name: "Validator NotFound",
+			route: routeSetup{
+				path:    "/validators/{id}",
+				handler: handlers.GetValidatorHandler(&mockValidatorService{}),
+			},
+			url:        "/validators/7?state=finalized",
+			expected:   http.StatusNotFound,
+			expectBody: "Validator not found",
+		},
+		{
+			name: "Validator StateNotFound",
+			route: routeSetup{
+				path:    "/validators/{id}",
+				handler: handlers.GetValidatorHandler(&mockValidatorService{}),
+			},
+			url:        "/validators/42?state=99999999",
+			expected:   http.StatusNotFound,
+			expectBody: "State not found",
+		},
+		{
+			name: "Validator Success",
+			route: routeSetup{
+				path:    "/validators/{id}",
+				handler: handlers.GetValidatorHandler(&mockValidatorService{}),
+			},
+			url:        "/validators/42",
+			expected:   http.StatusOK,
+			expectBody: `"activation_epoch":10,"exit_epoch":null,"withdrawable_epoch":null`,
+		},
+		{
+			name: "ValidatorRewards BadRequest",
+			route: routeSetup{
+				path:    "/validators/{id}/rewards",
+				handler: handlers.GetValidatorRewardsHandler(&mockValidatorRewardsService{}),
+			},
+			url:        "/validators/42/rewards?from_epoch=10",
+			expected:   http.StatusBadRequest,
+			expectBody: "Invalid to epoch number",
+		},
+		{
+			name: "ValidatorRewards RangeTooLarge",
+			route: routeSetup{
+				path:    "/validators/{id}/rewards",
+				handler: handlers.GetValidatorRewardsHandler(&mockValidatorRewardsService{}),
+			},
+			url:        "/validators/42/rewards?from_epoch=10&to_epoch=20",
+			expected:   http.StatusBadRequest,
+			expectBody: "Epoch range too large",
+		},
+		{
+			name: "ValidatorRewards NotFound",
+			route: routeSetup{
+				path:    "/validators/{id}/rewards",
+				handler: handlers.GetValidatorRewardsHandler(&mockValidatorRewardsService{}),
+			},
+			url:        "/validators/7/rewards?from_epoch=10&to_epoch=10",
+			expected:   http.StatusNotFound,
+			expectBody: "Validator not found",
+		},
+		{
+			name: "ValidatorRewards Success",
+			route: routeSetup{
+				path:    "/validators/{id}/rewards",
+				handler: handlers.GetValidatorRewardsHandler(&mockValidatorRewardsService{}),
+			},
+			url:        "/validators/42/rewards?from_epoch=10&to_epoch=10",
+			expected:   http.StatusOK,
+			expectBody: `"attestation_inactivity_gwei":"-1"`,
+		},
+		// ProposerDuties tests
+		{
+			name: "ProposerDuties BadRequest",
+			route: routeSetup{
+				path:    "/proposerduties/{epoch}",
+				handler: handlers.GetProposerDutiesHandler(&mockProposerDutyService{}),
+			},
+			url:        "/proposerduties/10?validators=42,0x1234",
+			expected:   http.StatusBadRequest,
+			expectBody: "Invalid validator identifier",
+		},
+		{
+			name: "ProposerDuties BeyondLookahead",
+			route: routeSetup{
+				path:    "/proposerduties/{epoch}",
+				handler: handlers.GetProposerDutiesHandler(&mockProposerDutyService{}),
+			},
+			url:        "/proposerduties/101",
+			expected:   http.StatusBadRequest,
+			expectBody: "Epoch is beyond the proposer lookahead",
+		},
+		{
+			name: "ProposerDuties Success",
+			route: routeSetup{
+				path:    "/proposerduties/{epoch}",
+				handler: handlers.GetProposerDutiesHandler(&mockProposerDutyService{}),
+			},
+			url:        "/proposerduties/10?validators=42",
+			expected:   http.StatusOK,
+			expectBody: `"proposers":[{"validator_index":"42","pubkey":"0xabc","slot":320}]`,
+		},
+		// AttesterDuties tests
+		{
+			name: "AttesterDuties BadRequest",
+			route: routeSetup{
+				path:    "/attesterduties/{epoch}",
+				handler: handlers.GetAttesterDutiesHandler(&mockAttesterDutyService{}),
+			},
+			url:        "/attesterduties/10?validators=42,0xabc",
+			expected:   http.StatusBadRequest,
+			expectBody: "Invalid validator index",
+		},
+		{
+			name: "AttesterDuties TooManyValidators",
+			route: routeSetup{
+				path:    "/attesterduties/{epoch}",
+				handler: handlers.GetAttesterDutiesHandler(&mockAttesterDutyService{}),
+			},
+			url:        "/attesterduties/10?validators=1,2,3",
+			expected:   http.StatusBadRequest,
+			expectBody: "Too many validators",
+		},
+		{
+			name: "AttesterDuties Success All Committees",
+			route: routeSetup{
+				path:    "/attesterduties/{epoch}",
+				handler: handlers.GetAttesterDutiesHandler(&mockAttesterDutyService{}),
+			},
+			url:        "/attesterduties/10",
+			expected:   http.StatusOK,
+			expectBody: `"members":[{"validator_index":"42","position":7}],"index":3,"slot":320`,
+		},
+		{
+			name: "AttesterDuties Success",
+			route: routeSetup{
+				path:    "/attesterduties/{epoch}",
+				handler: handlers.GetAttesterDutiesHandler(&mockAttesterDutyService{}),
+			},
+			url:        "/attesterduties/10?validators=42",
+			expected:   http.StatusOK,
+			expectBody: `"members":[{"validator_index":"42","pubkey":"0xabc","position":7}],"index":3,"slot":320`,
+		},
 		// SyncDuties tests
 		{
 			name: "SyncDuties BadRequest",
 			route: routeSetup{
-				path:    "/syncduties/{slot}",
+				path:    "/syncduties/{id}",
 				handler: handlers.GetSyncDutiesHandler(&mockSyncDutyService{}),
 			},
-			url:        "/syncduties/not-a-number",
+			url:        "/syncduties/0x1234",
 			expected:   http.StatusBadRequest,
-			expectBody: "Invalid slot number",
+			expectBody: "Invalid block identifier",
+		},
+		{
+			name: "SyncDuties Success Named Block",
+			route: routeSetup{
+				path:    "/syncduties/{id}",
+				handler: handlers.GetSyncDutiesHandler(&mockSyncDutyService{}),
+			},
+			url:        "/syncduties/finalized",
+			expected:   http.StatusOK,
+			expectBody: `"block_root":"0xroot"`,
 		},
 		{
 			name: "SyncDuties Success",
 			route: routeSetup{
-				path:    "/syncduties/{slot}",
+				path:    "/syncduties/{id}",
 				handler: handlers.GetSyncDutiesHandler(&mockSyncDutyService{}),
 			},
 			url:        "/syncduties/123456",
 			expected:   http.StatusOK,
 			expectBody: "0xabc",
 		},
+		{
+			name: "SyncDuties Success Keeps Committee Order",
+			route: routeSetup{
+				path:    "/syncduties/{id}",
+				handler: handlers.GetSyncDutiesHandler(&mockSyncDutyService{}),
+			},
+			url:        "/syncduties/123456",
+			expected:   http.StatusOK,
+			expectBody: `{"validator_index":"1","pubkey":"0xabc","position":2}`,
+		},
 		{
 			name: "SyncDuties InternalServerError",
 			route: routeSetup{
-				path:    "/syncduties/{slot}",
+				path:    "/syncduties/{id}",
 				handler: handlers.GetSyncDutiesHandler(&mockSyncDutyService{returnError: true}),
 			},
 			url:        "/syncduties/123456",
 			expected:   http.StatusInternalServerError,
 			expectBody: "Failed to retrieve sync duties",
 		},
+		// Rate limit tests
+		{
+			name: "RateLimit TooManyRequests",
+			route: routeSetup{
+				path:    "/time/timestamp",
+				handler: handlers.RateLimitMiddleware(limiter)(handlers.GetSlotTimestampHandler(testClock)).ServeHTTP,
+			},
+			url:           "/time/timestamp?slot=1",
+			expected:      http.StatusTooManyRequests,
+			expectHeaders: map[string]string{"Retry-After": "10", "Content-Type": "application/json"},
+			expectBody: `{"message":"Rate limit exceeded",` +
+				`"details":"0.1 requests per second: rate limit exceeded","code":429}`,
+		},
+		{
+			name: "RateLimit Other Route Allowed",
+			route: routeSetup{
+				path:    "/time/slot",
+				handler: handlers.RateLimitMiddleware(limiter)(handlers.GetSlotAtTimeHandler(testClock)).ServeHTTP,
+			},
+			url:        "/time/slot?timestamp=1606824035",
+			expected:   http.StatusOK,
+			expectBody: `"slot":1`,
+		},
+		// Admin tests
+		{
+			name: "ReloadBuilders Unauthorized",
+			route: routeSetup{
+				path:    "/admin/builders/reload",
+				handler: handlers.AdminTokenMiddleware("secret")(handlers.ReloadBuildersHandler(&mockBuilderRegistry{})).ServeHTTP,
+			},
+			url:           "/admin/builders/reload",
+			headers:       map[string]string{"Authorization": "Bearer wrong"},
+			expected:      http.StatusUnauthorized,
+			expectHeaders: map[string]string{"WWW-Authenticate": "Bearer"},
+			expectBody:    `{"message":"Invalid or missing admin token","code":401}`,
+		},
+		{
+			name: "ReloadBuilders Success",
+			route: routeSetup{
+				path:    "/admin/builders/reload",
+				handler: handlers.AdminTokenMiddleware("secret")(handlers.ReloadBuildersHandler(&mockBuilderRegistry{})).ServeHTTP,
+			},
+			url:        "/admin/builders/reload",
+			headers:    map[string]string{"Authorization": "Bearer secret"},
+			expected:   http.StatusOK,
+			expectBody: `{"builders":[{"name":"Titan"}]}`,
+		},
+		{
+			name: "ReloadBuilders InternalServerError",
+			route: routeSetup{
+				path:    "/admin/builders/reload",
+				handler: handlers.AdminTokenMiddleware("secret")(handlers.ReloadBuildersHandler(&mockBuilderRegistry{returnError: true})).ServeHTTP,
+			},
+			url:        "/admin/builders/reload",
+			headers:    map[string]string{"Authorization": "Bearer secret"},
+			expected:   http.StatusInternalServerError,
+			expectBody: "Failed to reload builder registry",
+		},
+		// Health tests
+		{
+			name: "Liveness Success",
+			route: routeSetup{
+				path:    "/healthz",
+				handler: handlers.GetLivenessHandler(),
+			},
+			url:        "/healthz",
+			expected:   http.StatusOK,
+			expectBody: `"status":"ok"`,
+		},
+		{
+			name: "Readiness Success",
+			route: routeSetup{
+				path:    "/readyz",
+				handler: handlers.GetReadinessHandler(&mockReadinessService{ready: true}),
+			},
+			url:        "/readyz",
+			expected:   http.StatusOK,
+			expectBody: `"ready":true}`,
+		},
+		{
+			name: "Readiness ServiceUnavailable",
+			route: routeSetup{
+				path:    "/readyz",
+				handler: handlers.GetReadinessHandler(&mockReadinessService{}),
+			},
+			url:        "/readyz",
+			expected:   http.StatusServiceUnavailable,
+			expectBody: `"execution":{"head_lag":0,"head_block":0,"ready":false,"reachable":true,"is_syncing":true}`,
+		},
 	}
 
 	for _, tt := range testCases {
@@ -130,12 +698,20 @@ func TestHandlers(t *testing.T) {
 			r.HandleFunc(tt.route.path, tt.route.handler)
 
 			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+
 			resp := httptest.NewRecorder()
 
 			r.ServeHTTP(resp, req)
 
 			require.Equal(t, tt.expected, resp.Code)
 			require.Contains(t, resp.Body.String(), tt.expectBody)
+
+			for name, value := range tt.expectHeaders {
+				require.Equal(t, value, resp.Header().Get(name), name)
+			}
 		})
 	}
 }
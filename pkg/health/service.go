@@ -0,0 +1,210 @@
+package health
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+)
+
+// DefaultMaxHeadLag is the default number of slots an upstream head may trail the current slot.
+const DefaultMaxHeadLag = 4
+
+// DefaultTimeout is the default time a readiness check waits for the execution client.
+const DefaultTimeout = 5 * time.Second
+
+// BeaconService reports the status of the configured beacon nodes, as last recorded by its
+// background health checks. It is satisfied by *beacon.Service.
+type BeaconService interface {
+	NodeStatuses() []beacon.NodeStatus
+}
+
+// ExecutionClient is the subset of the execution-layer client used for readiness checks.
+// It is satisfied by *ethclient.Client.
+type ExecutionClient interface {
+	SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Clock tells the current slot and the slot of a point in time. It is satisfied by *chaintime.Clock.
+type Clock interface {
+	CurrentSlot() uint64
+	SlotAt(t time.Time) (uint64, error)
+}
+
+// BeaconNodeReport is the readiness of a single beacon node. Readiness is served without
+// authentication, so the node is only identified by the scheme and host of its URL and
+// errors are left out; they are reported at the admin beacon nodes endpoint.
+type BeaconNodeReport struct {
+	// LastChecked is when the node was last probed, zero before the first health check.
+	LastChecked time.Time `json:"last_checked"`
+	URL         string    `json:"url"`
+	// HeadLag is the number of slots the node's head trails the current slot.
+	HeadLag  uint64 `json:"head_lag"`
+	HeadSlot uint64 `json:"head_slot"`
+	Ready    bool   `json:"ready"`
+	// Healthy reports whether the node answered its last health check.
+	Healthy      bool `json:"healthy"`
+	IsSyncing    bool `json:"is_syncing"`
+	IsOptimistic bool `json:"is_optimistic"`
+	ELOffline    bool `json:"el_offline"`
+}
+
+// BeaconReport is the readiness of the beacon nodes. They are ready when any node is, since
+// requests fail over to it.
+type BeaconReport struct {
+	Nodes []BeaconNodeReport `json:"nodes"`
+	Ready bool               `json:"ready"`
+}
+
+// ExecutionReport is the readiness of the execution client. Errors, which may reveal the
+// endpoint URL, are logged rather than reported.
+type ExecutionReport struct {
+	// HeadLag is the number of slots the head block trails the current slot.
+	HeadLag   uint64 `json:"head_lag"`
+	HeadBlock uint64 `json:"head_block"`
+	Ready     bool   `json:"ready"`
+	// Reachable reports whether the sync status and head block could be queried.
+	Reachable bool `json:"reachable"`
+	IsSyncing bool `json:"is_syncing"`
+}
+
+// Report is the readiness of the service and of each upstream it depends on.
+type Report struct {
+	Beacon    BeaconReport    `json:"beacon"`
+	Execution ExecutionReport `json:"execution"`
+	Ready     bool            `json:"ready"`
+}
+
+// Service checks whether the upstreams are reachable and synced.
+type Service struct {
+	BeaconService BeaconService
+	ExecClient    ExecutionClient
+	Clock         Clock
+	// MaxHeadLag is the number of slots an upstream head may trail the current slot and
+	// still be ready (DefaultMaxHeadLag when zero).
+	MaxHeadLag uint64
+	// Timeout bounds the time a readiness check waits for the execution client (DefaultTimeout when zero).
+	Timeout time.Duration
+}
+
+// NewService creates a new health service instance.
+func NewService(svc BeaconService, client ExecutionClient, clock Clock) *Service {
+	return &Service{
+		BeaconService: svc,
+		ExecClient:    client,
+		Clock:         clock,
+		MaxHeadLag:    DefaultMaxHeadLag,
+		Timeout:       DefaultTimeout,
+	}
+}
+
+// Readiness reports on the beacon nodes from the status recorded by their background health checks
+// (health and /eth/v1/node/syncing), and queries the execution client (eth_syncing and its head
+// block). The service is ready when at least one beacon node and the execution client are reachable,
+// not syncing and within MaxHeadLag of the current slot.
+func (s *Service) Readiness(ctx context.Context) Report {
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	report := Report{
+		Beacon:    s.checkBeacon(),
+		Execution: s.checkExecution(ctx),
+	}
+
+	report.Ready = report.Beacon.Ready && report.Execution.Ready
+
+	return report
+}
+
+// checkBeacon reports on each beacon node from its last recorded status. The node's head is
+// compared to the current slot, so a status gone stale makes the node lag behind.
+func (s *Service) checkBeacon() BeaconReport {
+	statuses := s.BeaconService.NodeStatuses()
+	report := BeaconReport{
+		Nodes: make([]BeaconNodeReport, 0, len(statuses)),
+	}
+
+	for _, status := range statuses {
+		node := BeaconNodeReport{
+			LastChecked:  status.LastChecked,
+			URL:          beacon.RedactURL(status.URL),
+			HeadSlot:     status.HeadSlot,
+			Healthy:      status.Healthy && !status.LastChecked.IsZero(),
+			IsSyncing:    status.IsSyncing,
+			IsOptimistic: status.IsOptimistic,
+			ELOffline:    status.ELOffline,
+		}
+
+		// Nodes are only ready once a health check has seen them synced.
+		if node.Healthy {
+			node.HeadLag = s.headLag(status.HeadSlot)
+			node.Ready = !status.IsSyncing && !status.IsOptimistic && !status.ELOffline &&
+				node.HeadLag <= s.maxHeadLag()
+		}
+
+		report.Ready = report.Ready || node.Ready
+		report.Nodes = append(report.Nodes, node)
+	}
+
+	return report
+}
+
+// checkExecution queries the sync status and head block of the execution client.
+func (s *Service) checkExecution(ctx context.Context) ExecutionReport {
+	var report ExecutionReport
+
+	progress, err := s.ExecClient.SyncProgress(ctx)
+	if err != nil {
+		log.Printf("[Health] %v", pkgerrors.Wrap(err, "query sync status"))
+		return report
+	}
+
+	header, err := s.ExecClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		log.Printf("[Health] %v", pkgerrors.Wrap(err, "fetch head block"))
+		return report
+	}
+
+	report.Reachable = true
+
+	headSlot, err := s.Clock.SlotAt(time.Unix(int64(header.Time), 0)) //nolint:gosec // block timestamps fit int64
+	if err != nil {
+		log.Printf("[Health] %v", pkgerrors.Wrap(err, "resolve head block slot"))
+		return report
+	}
+
+	report.IsSyncing = progress != nil
+	report.HeadBlock = header.Number.Uint64()
+	report.HeadLag = s.headLag(headSlot)
+	report.Ready = !report.IsSyncing && report.HeadLag <= s.maxHeadLag()
+
+	return report
+}
+
+// headLag returns the number of slots headSlot trails the current slot.
+func (s *Service) headLag(headSlot uint64) uint64 {
+	if current := s.Clock.CurrentSlot(); current > headSlot {
+		return current - headSlot
+	}
+
+	return 0
+}
+
+func (s *Service) maxHeadLag() uint64 {
+	if s.MaxHeadLag == 0 {
+		return DefaultMaxHeadLag
+	}
+
+	return s.MaxHeadLag
+}
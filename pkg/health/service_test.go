@@ -0,0 +1,150 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/chaintime"
+	"github.com/powerslider/ethereum-validator-api/pkg/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// genesis is the mainnet genesis time.
+var genesis = time.Unix(1606824023, 0)
+
+// currentSlot is the slot the test clock is in.
+const currentSlot = 1000
+
+func newTestClock() *chaintime.Clock {
+	clock := chaintime.NewClock(genesis, 12, 32, 256)
+	clock.Now = func() time.Time { return genesis.Add(currentSlot * 12 * time.Second) }
+
+	return clock
+}
+
+type mockBeaconService struct {
+	statuses []beacon.NodeStatus
+}
+
+func (m *mockBeaconService) NodeStatuses() []beacon.NodeStatus {
+	return m.statuses
+}
+
+type mockExecutionClient struct {
+	progress *ethereum.SyncProgress
+	err      error
+	headSlot uint64
+}
+
+func (m *mockExecutionClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	return m.progress, m.err
+}
+
+func (m *mockExecutionClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{
+		Number: big.NewInt(20_000_000),
+		Time:   uint64(genesis.Unix()) + m.headSlot*12, //nolint:gosec // test genesis is after 1970
+	}, nil
+}
+
+func TestReadiness(t *testing.T) {
+	t.Parallel()
+
+	checked := genesis.Add(currentSlot * 12 * time.Second)
+	synced := beacon.NodeStatus{
+		URL: "https://synced/v1/secret-key", Healthy: true, HeadSlot: currentSlot, LastChecked: checked,
+	}
+	lagging := beacon.NodeStatus{URL: "http://lagging", Healthy: true, HeadSlot: currentSlot - 10, LastChecked: checked}
+	down := beacon.NodeStatus{URL: "http://down", LastError: "probe node health: connection refused", LastChecked: checked}
+
+	t.Run("ready when a beacon node and the execution client are synced", func(t *testing.T) {
+		t.Parallel()
+
+		beaconSvc := &mockBeaconService{statuses: []beacon.NodeStatus{synced, down}}
+		svc := health.NewService(beaconSvc, &mockExecutionClient{headSlot: currentSlot - 1}, newTestClock())
+
+		report := svc.Readiness(context.Background())
+
+		assert.True(t, report.Ready)
+		assert.True(t, report.Beacon.Ready)
+		require.Len(t, report.Beacon.Nodes, 2)
+		assert.True(t, report.Beacon.Nodes[0].Ready)
+		assert.False(t, report.Beacon.Nodes[1].Ready)
+		assert.True(t, report.Beacon.Nodes[0].Healthy)
+		assert.False(t, report.Beacon.Nodes[1].Healthy)
+		// Node URLs are reported without the path that may hold an API key.
+		assert.Equal(t, "https://synced", report.Beacon.Nodes[0].URL)
+		assert.Equal(t, health.ExecutionReport{HeadBlock: 20_000_000, HeadLag: 1, Ready: true, Reachable: true},
+			report.Execution)
+	})
+
+	t.Run("not ready when beacon heads lag behind", func(t *testing.T) {
+		t.Parallel()
+
+		beaconSvc := &mockBeaconService{statuses: []beacon.NodeStatus{lagging, down}}
+		svc := health.NewService(beaconSvc, &mockExecutionClient{headSlot: currentSlot}, newTestClock())
+
+		report := svc.Readiness(context.Background())
+
+		assert.False(t, report.Ready)
+		assert.False(t, report.Beacon.Ready)
+		assert.Equal(t, uint64(10), report.Beacon.Nodes[0].HeadLag)
+		assert.True(t, report.Execution.Ready)
+	})
+
+	t.Run("not ready before the first health check", func(t *testing.T) {
+		t.Parallel()
+
+		// Nodes are assumed healthy until checked, so they get requests right away.
+		beaconSvc := &mockBeaconService{statuses: []beacon.NodeStatus{{URL: "http://unchecked", Healthy: true}}}
+		svc := health.NewService(beaconSvc, &mockExecutionClient{headSlot: currentSlot}, newTestClock())
+
+		report := svc.Readiness(context.Background())
+
+		assert.False(t, report.Ready)
+		assert.False(t, report.Beacon.Nodes[0].Healthy)
+		assert.True(t, report.Beacon.Nodes[0].LastChecked.IsZero())
+	})
+
+	t.Run("lag threshold is configurable", func(t *testing.T) {
+		t.Parallel()
+
+		beaconSvc := &mockBeaconService{statuses: []beacon.NodeStatus{lagging}}
+		svc := health.NewService(beaconSvc, &mockExecutionClient{headSlot: currentSlot}, newTestClock())
+		svc.MaxHeadLag = 10
+
+		assert.True(t, svc.Readiness(context.Background()).Ready)
+	})
+
+	t.Run("not ready when the execution client is syncing", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockExecutionClient{progress: &ethereum.SyncProgress{CurrentBlock: 1, HighestBlock: 2}}
+		svc := health.NewService(&mockBeaconService{statuses: []beacon.NodeStatus{synced}}, client, newTestClock())
+
+		report := svc.Readiness(context.Background())
+
+		assert.False(t, report.Ready)
+		assert.True(t, report.Execution.IsSyncing)
+		assert.False(t, report.Execution.Ready)
+	})
+
+	t.Run("not ready when the execution client is unreachable", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockExecutionClient{err: errors.New("connection refused")}
+		svc := health.NewService(&mockBeaconService{statuses: []beacon.NodeStatus{synced}}, client, newTestClock())
+
+		report := svc.Readiness(context.Background())
+
+		assert.False(t, report.Ready)
+		assert.False(t, report.Execution.Reachable)
+	})
+}
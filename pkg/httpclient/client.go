@@ -0,0 +1,131 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"os"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/config"
+	"github.com/powerslider/ethereum-validator-api/pkg/tracing"
+)
+
+// New builds an HTTP client for an upstream node honoring the endpoint's
+// timeout, static headers and TLS options.
+func New(cfg config.EndpointConfig) (*http.Client, error) {
+	tlsConfig, err := newTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Timeout: cfg.Timeout,
+		// Outbound requests are traced, carrying the trace context to the node.
+		Transport: tracing.Transport(&headerTransport{
+			headers: cfg.Headers,
+			next:    transport,
+		}),
+	}, nil
+}
+
+// newTLSConfig builds the TLS client configuration from the endpoint options.
+func newTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		//nolint:gosec // explicitly opted into by configuration, e.g. for self-signed local nodes.
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "read CA file")
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, pkgerrors.Errorf("no certificates found in CA file %s", cfg.CAFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "load client certificate")
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// headerTransport adds a fixed set of headers to every outgoing request.
+type headerTransport struct {
+	headers map[string]string
+	next    http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.headers) == 0 {
+		//nolint:wrapcheck
+		return t.next.RoundTrip(req)
+	}
+
+	// RoundTrippers must not modify the original request.
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+
+	//nolint:wrapcheck
+	return t.next.RoundTrip(req)
+}
+
+// DialRPC connects a JSON-RPC client to the first URL of the endpoint (HTTP or websocket)
+// honoring its timeout, headers and TLS options.
+func DialRPC(ctx context.Context, cfg config.EndpointConfig) (*rpc.Client, error) {
+	httpClient, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(http.Header, len(cfg.Headers))
+	for name, value := range cfg.Headers {
+		headers.Set(name, value)
+	}
+
+	// Headers are also passed to the RPC client so they apply to websocket endpoints.
+	rpcClient, err := rpc.DialOptions(ctx, cfg.URLs[0], rpc.WithHTTPClient(httpClient), rpc.WithHeaders(headers))
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "dial rpc endpoint")
+	}
+
+	return rpcClient, nil
+}
+
+// DialExecutionClient connects to the execution-layer node of cfg using its own
+// timeout, headers and TLS settings.
+func DialExecutionClient(ctx context.Context, cfg *config.Config) (*ethclient.Client, error) {
+	execCfg, err := cfg.Execution()
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "load execution endpoint config")
+	}
+
+	rpcClient, err := DialRPC(ctx, execCfg)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "dial execution endpoint")
+	}
+
+	return ethclient.NewClient(rpcClient), nil
+}
@@ -0,0 +1,288 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/blockreward"
+	"github.com/powerslider/ethereum-validator-api/pkg/store"
+	"github.com/powerslider/ethereum-validator-api/pkg/syncduties"
+)
+
+// progressName is the name the indexer saves its progress under.
+const progressName = "indexer"
+
+// BlockRewardService computes (and stores) the block reward of a slot.
+type BlockRewardService interface {
+	GetBlockReward(ctx context.Context, id beacon.BlockID) (*blockreward.Result, error)
+}
+
+// SyncDutyService computes (and stores) the sync committee of a slot.
+type SyncDutyService interface {
+	GetSyncDuties(ctx context.Context, id beacon.BlockID) (*syncduties.Duties, error)
+}
+
+// BeaconService reports the chain head. It should not be cached, or new heads are seen late.
+type BeaconService interface {
+	GetBeaconHeader(ctx context.Context, id beacon.BlockID) (*beacon.BlockHeaderResponse, error)
+}
+
+// ProgressStore persists the last indexed slot. It is satisfied by *store.Store.
+type ProgressStore interface {
+	GetProgress(name string, v any) error
+	PutProgress(name string, v any) error
+}
+
+// Status reports the progress of the indexer.
+type Status struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	LastError string    `json:"last_error,omitempty"`
+	// LastIndexedSlot is the highest slot whose reward and duties were computed.
+	LastIndexedSlot uint64 `json:"last_indexed_slot"`
+	HeadSlot        uint64 `json:"head_slot"`
+	// Lag is the number of slots the indexer is behind the head.
+	Lag     uint64 `json:"lag"`
+	Running bool   `json:"running"`
+}
+
+// Service follows the chain head and precomputes the block reward and sync duties of every
+// new slot, so that they are served from the store instead of the beacon node. It indexes
+// on every head event passed to HandleHead, and polls the head in case events are lost.
+type Service struct {
+	BlockRewards BlockRewardService
+	SyncDuties   SyncDutyService
+	Beacon       BeaconService
+	// Progress is optional; without it indexing restarts from the head after a restart.
+	Progress ProgressStore
+	status   Status
+	mu       sync.RWMutex
+	// heads wakes Run up when a new head is announced.
+	heads chan struct{}
+	// Interval is how often the head is polled without head events.
+	Interval time.Duration
+	// rewind is one past the slot to index again from, zero when there is nothing to redo.
+	rewind uint64
+}
+
+// NewService creates a new indexer polling the head every interval between head events.
+func NewService(
+	rewards BlockRewardService,
+	duties SyncDutyService,
+	beaconSvc BeaconService,
+	progress ProgressStore,
+	interval time.Duration,
+) *Service {
+	return &Service{
+		BlockRewards: rewards,
+		SyncDuties:   duties,
+		Beacon:       beaconSvc,
+		Progress:     progress,
+		Interval:     interval,
+		heads:        make(chan struct{}, 1),
+	}
+}
+
+// Status returns the current progress of the indexer.
+func (s *Service) Status() Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	status := s.status
+	if status.HeadSlot > status.LastIndexedSlot {
+		status.Lag = status.HeadSlot - status.LastIndexedSlot
+	}
+
+	return status
+}
+
+// Run indexes new slots until ctx is canceled, resuming from the last indexed slot.
+func (s *Service) Run(ctx context.Context) {
+	s.mu.Lock()
+	s.status.Running = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.status.Running = false
+		s.mu.Unlock()
+	}()
+
+	next, resumed := s.loadProgress()
+
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		head, err := s.fetchHeadSlot(ctx)
+		if err == nil {
+			// Without saved progress, start at the head rather than replaying history.
+			if !resumed {
+				next, resumed = head, true
+			}
+
+			if from, ok := s.takeRewind(); ok && from < next {
+				next = from
+			}
+
+			next, err = s.indexUpTo(ctx, next, head)
+		}
+
+		s.setError(err)
+
+		if err != nil && ctx.Err() == nil {
+			log.Printf("[Indexer] %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.heads:
+		}
+	}
+}
+
+// HandleHead makes the indexer catch up with a new head. Other events are ignored. It does
+// not block: heads announced while a round is running are indexed by the next one.
+func (s *Service) HandleHead(event beacon.Event) {
+	if _, ok := event.(*beacon.HeadEvent); !ok {
+		return
+	}
+
+	select {
+	case s.heads <- struct{}{}:
+	default:
+	}
+}
+
+// Rewind makes the indexer index fromSlot and later slots again, e.g. because a reorg replaced
+// their blocks. It is meant to be a beacon.ReorgHook.
+func (s *Service) Rewind(fromSlot uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rewind == 0 || fromSlot+1 < s.rewind {
+		s.rewind = fromSlot + 1
+	}
+}
+
+// takeRewind returns and clears the slot requested by Rewind, if any.
+func (s *Service) takeRewind() (uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	from, ok := s.rewind-1, s.rewind != 0
+	s.rewind = 0
+
+	return from, ok
+}
+
+// indexUpTo indexes the slots in [next, head] in order and returns the next slot to index.
+// It stops at the first slot that fails, to be retried on the next round.
+func (s *Service) indexUpTo(ctx context.Context, next, head uint64) (uint64, error) {
+	for ; next <= head; next++ {
+		if err := s.indexSlot(ctx, next); err != nil {
+			return next, pkgerrors.Wrapf(err, "index slot %d", next)
+		}
+
+		s.saveProgress(next)
+	}
+
+	return next, nil
+}
+
+// indexSlot computes the block reward and sync duties of slot. Missed slots and
+// pre-Merge slots have no reward and are skipped.
+func (s *Service) indexSlot(ctx context.Context, slot uint64) error {
+	id := beacon.SlotBlockID(slot)
+
+	_, err := s.BlockRewards.GetBlockReward(ctx, id)
+
+	switch cause := pkgerrors.Cause(err); {
+	case err == nil,
+		errors.Is(cause, beacon.ErrSlotMissedOrDoesNotExist),
+		errors.Is(cause, beacon.ErrNoExecutionPayload):
+	default:
+		return pkgerrors.Wrap(err, "compute block reward")
+	}
+
+	_, err = s.SyncDuties.GetSyncDuties(ctx, id)
+
+	switch cause := pkgerrors.Cause(err); {
+	case err == nil,
+		errors.Is(cause, beacon.ErrSlotWasMissed),
+		errors.Is(cause, beacon.ErrDutiesNotFound):
+		return nil
+	default:
+		return pkgerrors.Wrap(err, "compute sync duties")
+	}
+}
+
+// fetchHeadSlot returns the current head slot and records it in the status.
+func (s *Service) fetchHeadSlot(ctx context.Context) (uint64, error) {
+	header, err := s.Beacon.GetBeaconHeader(ctx, beacon.BlockHead)
+	if err != nil {
+		return 0, pkgerrors.Wrap(err, "fetch head header")
+	}
+
+	head := header.Data.Header.Message.Slot
+
+	s.mu.Lock()
+	s.status.HeadSlot = head
+	s.mu.Unlock()
+
+	return head, nil
+}
+
+// loadProgress returns the slot after the last indexed one and whether progress was found.
+func (s *Service) loadProgress() (uint64, bool) {
+	if s.Progress == nil {
+		return 0, false
+	}
+
+	var last uint64
+	if err := s.Progress.GetProgress(progressName, &last); err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			log.Printf("[Indexer] %v", err)
+		}
+
+		return 0, false
+	}
+
+	s.mu.Lock()
+	s.status.LastIndexedSlot = last
+	s.mu.Unlock()
+
+	return last + 1, true
+}
+
+// saveProgress records slot as the last indexed one.
+func (s *Service) saveProgress(slot uint64) {
+	s.mu.Lock()
+	s.status.LastIndexedSlot = slot
+	s.status.UpdatedAt = time.Now().UTC()
+	s.mu.Unlock()
+
+	if s.Progress == nil {
+		return
+	}
+
+	if err := s.Progress.PutProgress(progressName, slot); err != nil {
+		log.Printf("[Indexer] %v", err)
+	}
+}
+
+func (s *Service) setError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status.LastError = ""
+	if err != nil {
+		s.status.LastError = err.Error()
+	}
+}
@@ -0,0 +1,137 @@
+package indexer_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/blockreward"
+	"github.com/powerslider/ethereum-validator-api/pkg/indexer"
+	"github.com/powerslider/ethereum-validator-api/pkg/store"
+	"github.com/powerslider/ethereum-validator-api/pkg/syncduties"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockServices struct {
+	progress map[string]uint64
+	indexed  []uint64
+	// head is the head slot, 105 when zero.
+	head uint64
+	mu   sync.Mutex
+}
+
+func (m *mockServices) GetBlockReward(ctx context.Context, id beacon.BlockID) (*blockreward.Result, error) {
+	slot, _ := id.Slot()
+	if slot == 102 {
+		return nil, pkgerrors.Wrap(beacon.ErrSlotMissedOrDoesNotExist, "fetch block header")
+	}
+
+	return &blockreward.Result{Slot: slot}, nil
+}
+
+func (m *mockServices) GetSyncDuties(ctx context.Context, id beacon.BlockID) (*syncduties.Duties, error) {
+	slot, _ := id.Slot()
+
+	m.mu.Lock()
+	m.indexed = append(m.indexed, slot)
+	m.mu.Unlock()
+
+	return &syncduties.Duties{Slot: slot}, nil
+}
+
+func (m *mockServices) GetBeaconHeader(ctx context.Context, id beacon.BlockID) (*beacon.BlockHeaderResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	resp := &beacon.BlockHeaderResponse{}
+	resp.Data.Header.Message.Slot = max(m.head, 105)
+
+	return resp, nil
+}
+
+func (m *mockServices) GetProgress(name string, v any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	last, ok := m.progress[name]
+	if !ok {
+		return store.ErrNotFound
+	}
+
+	*v.(*uint64) = last
+
+	return nil
+}
+
+func (m *mockServices) PutProgress(name string, v any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.progress[name] = v.(uint64)
+
+	return nil
+}
+
+func TestIndexerResumesFromProgress(t *testing.T) {
+	t.Parallel()
+
+	mocks := &mockServices{progress: map[string]uint64{"indexer": 100}}
+	svc := indexer.NewService(mocks, mocks, mocks, mocks, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go svc.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		return svc.Status().LastIndexedSlot == 105
+	}, time.Second, 10*time.Millisecond)
+
+	status := svc.Status()
+	assert.Equal(t, uint64(105), status.HeadSlot)
+	assert.Zero(t, status.Lag)
+	assert.Empty(t, status.LastError)
+
+	mocks.mu.Lock()
+	defer mocks.mu.Unlock()
+
+	// Slot 102 was missed but its sync duties are still indexed.
+	assert.Equal(t, []uint64{101, 102, 103, 104, 105}, mocks.indexed)
+	assert.Equal(t, uint64(105), mocks.progress["indexer"])
+}
+
+func TestIndexerFollowsHeadEvents(t *testing.T) {
+	t.Parallel()
+
+	mocks := &mockServices{progress: map[string]uint64{"indexer": 104}}
+	svc := indexer.NewService(mocks, mocks, mocks, mocks, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go svc.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		return svc.Status().LastIndexedSlot == 105
+	}, time.Second, 10*time.Millisecond)
+
+	mocks.mu.Lock()
+	mocks.head = 107
+	mocks.mu.Unlock()
+
+	// Other events do not wake the indexer up.
+	svc.HandleHead(&beacon.BlockEvent{Slot: 107})
+	assert.Never(t, func() bool {
+		return svc.Status().LastIndexedSlot != 105
+	}, 50*time.Millisecond, 10*time.Millisecond)
+
+	// The new head is indexed right away rather than at the next poll.
+	svc.HandleHead(&beacon.HeadEvent{Slot: 107})
+	require.Eventually(t, func() bool {
+		return svc.Status().LastIndexedSlot == 107
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,192 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	pkgerrors "github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader carries the request ID, either assigned by the caller or generated.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the length of request IDs accepted from callers.
+const maxRequestIDLength = 128
+
+var ErrInvalidLogConfig = errors.New("invalid log configuration")
+
+type contextKey struct{}
+
+// Setup installs a slog logger writing to w at level ("debug", "info", "warn" or "error") in
+// format ("json" or "text") as the default logger. Output of the standard log package goes
+// through it as well.
+func Setup(w io.Writer, level, format string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, pkgerrors.Wrapf(ErrInvalidLogConfig, "log level %q", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+
+	var handler slog.Handler
+
+	switch strings.ToLower(format) {
+	case "json":
+		handler = slog.NewJSONHandler(w, opts)
+	case "text":
+		handler = slog.NewTextHandler(w, opts)
+	default:
+		return nil, pkgerrors.Wrapf(ErrInvalidLogConfig, "log format %q", format)
+	}
+
+	logger := slog.New(handler)
+	slog.SetDefault(logger)
+
+	return logger, nil
+}
+
+// WithLogger returns a copy of ctx carrying logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the request-scoped logger of ctx, or the default logger outside of requests.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+// RecordError attaches the error behind a failed response to the request log entry.
+// w is the writer passed to the handler; it is a no-op for requests that are not logged.
+func RecordError(w http.ResponseWriter, err error) {
+	if recorder := recorderOf(w); recorder != nil {
+		recorder.err = err
+	}
+}
+
+// RecordSlot attaches the slot a request resolved to to the request log entry.
+// w is the writer passed to the handler; it is a no-op for requests that are not logged.
+func RecordSlot(w http.ResponseWriter, slot uint64) {
+	if recorder := recorderOf(w); recorder != nil {
+		recorder.slot = &slot
+	}
+}
+
+// recorderOf finds the recorder of the request log entry among the wrappers of w.
+func recorderOf(w http.ResponseWriter) *responseRecorder {
+	for w != nil {
+		if recorder, ok := w.(*responseRecorder); ok {
+			return recorder
+		}
+
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return nil
+		}
+
+		w = unwrapper.Unwrap()
+	}
+
+	return nil
+}
+
+// Middleware logs every request to the routes of a mux.Router once it is served: its request ID,
+// method, route template, status, latency and, when recorded by the handler, slot and error.
+// The request ID is taken from the X-Request-ID header or generated, and echoed in the response.
+// Handlers and the services they call log through the request-scoped logger, see FromContext.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = newRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+
+		logger := slog.Default().With("request_id", requestID)
+		if span := trace.SpanContextFromContext(r.Context()); span.HasTraceID() {
+			logger = logger.With("trace_id", span.TraceID().String())
+		}
+
+		start := time.Now()
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, r.WithContext(WithLogger(r.Context(), logger)))
+
+		attrs := []any{
+			"method", r.Method,
+			"route", routeTemplate(r),
+			"status", recorder.status,
+			"latency", time.Since(start),
+		}
+
+		if recorder.slot != nil {
+			attrs = append(attrs, "slot", *recorder.slot)
+		}
+
+		if recorder.err != nil {
+			attrs = append(attrs, "error", recorder.err.Error())
+		}
+
+		level := slog.LevelInfo
+
+		switch {
+		case recorder.status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case recorder.status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		logger.Log(r.Context(), level, "request", attrs...)
+	})
+}
+
+// routeTemplate returns the template of the matched route, or the path when there is none.
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+
+	return r.URL.Path
+}
+
+// newRequestID generates a random 128-bit request ID.
+func newRequestID() string {
+	var id [16]byte
+
+	_, _ = rand.Read(id[:])
+
+	return hex.EncodeToString(id[:])
+}
+
+// responseRecorder captures the status code written by a handler and what it recorded for the log entry.
+type responseRecorder struct {
+	http.ResponseWriter
+	err    error
+	slot   *uint64
+	status int
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController, which streaming handlers flush through.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
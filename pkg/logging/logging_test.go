@@ -0,0 +1,102 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMiddleware serves requests through a router logging to a buffer. It installs the
+// default logger, so it does not run in parallel.
+func TestMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+
+	_, err := logging.Setup(&buf, "info", "json")
+	require.NoError(t, err)
+
+	r := mux.NewRouter()
+	r.Use(logging.Middleware)
+	r.HandleFunc("/blockreward/{id}", func(w http.ResponseWriter, r *http.Request) {
+		logging.FromContext(r.Context()).InfoContext(r.Context(), "upstream call")
+		logging.RecordSlot(w, 42)
+		logging.RecordError(w, pkgerrors.Wrap(pkgerrors.New("connection refused"), "fetch block header"))
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	t.Run("propagates request ID", func(t *testing.T) {
+		buf.Reset()
+
+		req := httptest.NewRequest(http.MethodGet, "/blockreward/head", nil)
+		req.Header.Set(logging.RequestIDHeader, "abc-123")
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, "abc-123", rec.Header().Get(logging.RequestIDHeader))
+
+		entries := decodeEntries(t, &buf)
+		require.Len(t, entries, 2)
+
+		// The handler's own entry carries the request ID as well.
+		assert.Equal(t, "upstream call", entries[0]["msg"])
+		assert.Equal(t, "abc-123", entries[0]["request_id"])
+
+		entry := entries[1]
+		assert.Equal(t, "request", entry["msg"])
+		assert.Equal(t, "ERROR", entry["level"])
+		assert.Equal(t, "abc-123", entry["request_id"])
+		assert.Equal(t, "GET", entry["method"])
+		assert.Equal(t, "/blockreward/{id}", entry["route"])
+		assert.InDelta(t, 500, entry["status"], 0)
+		assert.InDelta(t, 42, entry["slot"], 0)
+		assert.Equal(t, "fetch block header: connection refused", entry["error"])
+	})
+
+	t.Run("generates request ID", func(t *testing.T) {
+		buf.Reset()
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/blockreward/1", nil))
+
+		requestID := rec.Header().Get(logging.RequestIDHeader)
+		assert.Len(t, requestID, 32)
+
+		entries := decodeEntries(t, &buf)
+		require.Len(t, entries, 2)
+		assert.Equal(t, requestID, entries[1]["request_id"])
+	})
+}
+
+func TestSetupInvalid(t *testing.T) {
+	var buf bytes.Buffer
+
+	_, err := logging.Setup(&buf, "verbose", "json")
+	require.ErrorIs(t, err, logging.ErrInvalidLogConfig)
+
+	_, err = logging.Setup(&buf, "info", "xml")
+	require.ErrorIs(t, err, logging.ErrInvalidLogConfig)
+}
+
+func decodeEntries(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var entries []map[string]any
+
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var entry map[string]any
+		require.NoError(t, dec.Decode(&entry))
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Outcomes of upstream calls.
+const (
+	OutcomeOK          = "ok"
+	OutcomeNotFound    = "not_found"
+	OutcomeTimeout     = "timeout"
+	OutcomeClientError = "4xx"
+	OutcomeServerError = "5xx"
+	// OutcomeError is any other failure, e.g. a refused connection or an undecodable response.
+	OutcomeError = "error"
+)
+
+// Upstreams that calls are made to.
+const (
+	UpstreamBeacon    = "beacon"
+	UpstreamExecution = "execution"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests served, by route, method and status code.",
+	}, []string{"route", "method", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency, by route, method and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	upstreamRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "upstream_requests_total",
+		Help: "Calls to upstream nodes, by upstream, method and outcome.",
+	}, []string{"upstream", "method", "outcome"})
+
+	upstreamDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "upstream_request_duration_seconds",
+		Help:    "Latency of calls to upstream nodes, by upstream, method and outcome.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"upstream", "method", "outcome"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		upstreamRequests,
+		upstreamDuration,
+	)
+}
+
+// Handler serves the collected metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Middleware records the count and latency of requests to the routes of a mux.Router,
+// labelled by route template so that path parameters do not multiply the series.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := r.URL.Path
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, r)
+
+		status := strconv.Itoa(recorder.status)
+		httpRequests.WithLabelValues(route, r.Method, status).Inc()
+		httpDuration.WithLabelValues(route, r.Method, status).Observe(time.Since(start).Seconds())
+	})
+}
+
+// ObserveUpstream records a call to an upstream that started at start.
+func ObserveUpstream(upstream, method, outcome string, start time.Time) {
+	upstreamRequests.WithLabelValues(upstream, method, outcome).Inc()
+	upstreamDuration.WithLabelValues(upstream, method, outcome).Observe(time.Since(start).Seconds())
+}
+
+// StatusOutcome classifies an upstream HTTP response status code.
+func StatusOutcome(status int) string {
+	switch {
+	case status < http.StatusBadRequest:
+		return OutcomeOK
+	case status == http.StatusNotFound:
+		return OutcomeNotFound
+	case status >= http.StatusInternalServerError:
+		return OutcomeServerError
+	default:
+		return OutcomeClientError
+	}
+}
+
+// ErrorOutcome classifies an upstream call that failed without a response.
+func ErrorOutcome(err error) string {
+	var netErr net.Error
+
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return OutcomeTimeout
+	}
+
+	return OutcomeError
+}
+
+// statusRecorder captures the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController, which streaming handlers flush through.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
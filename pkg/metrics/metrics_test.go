@@ -0,0 +1,52 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/powerslider/ethereum-validator-api/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	r := mux.NewRouter()
+	r.Use(metrics.Middleware)
+	r.HandleFunc("/things/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	r.Handle("/metrics", metrics.Handler())
+
+	for _, id := range []string{"1", "2"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/things/"+id, nil))
+	}
+
+	metrics.ObserveUpstream(metrics.UpstreamBeacon, "GetThing", metrics.OutcomeNotFound, time.Now())
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	// Requests are labelled by route template, not by path.
+	assert.Contains(t, body, `http_requests_total{method="GET",route="/things/{id}",status="418"} 2`)
+	assert.Contains(t, body, `upstream_requests_total{method="GetThing",outcome="not_found",upstream="beacon"} 1`)
+}
+
+func TestOutcomes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, metrics.OutcomeOK, metrics.StatusOutcome(http.StatusOK))
+	assert.Equal(t, metrics.OutcomeNotFound, metrics.StatusOutcome(http.StatusNotFound))
+	assert.Equal(t, metrics.OutcomeClientError, metrics.StatusOutcome(http.StatusBadRequest))
+	assert.Equal(t, metrics.OutcomeServerError, metrics.StatusOutcome(http.StatusBadGateway))
+	assert.Equal(t, metrics.OutcomeTimeout, metrics.ErrorOutcome(context.DeadlineExceeded))
+	assert.Equal(t, metrics.OutcomeError, metrics.ErrorOutcome(errors.New("connection refused")))
+}
@@ -0,0 +1,103 @@
+package proposerduties
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+)
+
+// ErrEpochBeyondLookahead is returned for epochs whose proposers cannot be known yet.
+var ErrEpochBeyondLookahead = errors.New("epoch is beyond the proposer lookahead")
+
+// Lookahead is the number of epochs after the current one whose proposers are known.
+// The proposers of an epoch are fixed by the RANDAO at the end of the epoch before the previous one.
+const Lookahead = 1
+
+type BeaconService interface {
+	GetProposerDuties(ctx context.Context, epoch uint64) (*beacon.ProposerDutiesResponse, error)
+}
+
+// Clock tells the current epoch. It is satisfied by *chaintime.Clock.
+type Clock interface {
+	CurrentSlot() uint64
+	EpochOf(slot uint64) uint64
+}
+
+// Proposer is the validator scheduled to propose the block of a slot.
+type Proposer struct {
+	ValidatorIndex string
+	Pubkey         string
+	Slot           uint64
+}
+
+// Duties holds the block proposers of an epoch, in slot order.
+type Duties struct {
+	// DependentRoot is the block root the duties were computed from. A reorg that replaces it
+	// reshuffles the proposers of the epoch.
+	DependentRoot string
+	Proposers     []Proposer
+	Epoch         uint64
+}
+
+type Service struct {
+	BeaconService BeaconService
+	Clock         Clock
+}
+
+func NewService(svc BeaconService, clock Clock) *Service {
+	return &Service{
+		BeaconService: svc,
+		Clock:         clock,
+	}
+}
+
+// GetProposerDuties returns the block proposers of an epoch. When validators (indices or public keys)
+// are given, only the slots of those validators are returned. Returns ErrEpochBeyondLookahead for
+// epochs after the next one.
+func (s *Service) GetProposerDuties(ctx context.Context, epoch uint64, validators []beacon.ValidatorID) (*Duties, error) {
+	if current := s.Clock.EpochOf(s.Clock.CurrentSlot()); epoch > current+Lookahead {
+		return nil, pkgerrors.Wrapf(ErrEpochBeyondLookahead, "epoch %d, current epoch is %d", epoch, current)
+	}
+
+	resp, err := s.BeaconService.GetProposerDuties(ctx, epoch)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch proposer duties")
+	}
+
+	// The beacon node returns the public key of every proposer along with its index.
+	proposers := make([]Proposer, 0, len(resp.Data))
+
+	for _, duty := range resp.Data {
+		if len(validators) > 0 && !matches(validators, duty) {
+			continue
+		}
+
+		proposers = append(proposers, Proposer{
+			Slot:           duty.Slot,
+			ValidatorIndex: duty.ValidatorIndex,
+			Pubkey:         duty.Pubkey,
+		})
+	}
+
+	slices.SortFunc(proposers, func(a, b Proposer) int {
+		return cmp.Compare(a.Slot, b.Slot)
+	})
+
+	return &Duties{
+		Epoch:         epoch,
+		DependentRoot: resp.DependentRoot,
+		Proposers:     proposers,
+	}, nil
+}
+
+// matches reports whether the duty belongs to one of validators.
+func matches(validators []beacon.ValidatorID, duty beacon.ProposerDuty) bool {
+	return slices.ContainsFunc(validators, func(id beacon.ValidatorID) bool {
+		return string(id) == duty.ValidatorIndex || strings.EqualFold(string(id), duty.Pubkey)
+	})
+}
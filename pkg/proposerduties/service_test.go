@@ -0,0 +1,72 @@
+package proposerduties_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/chaintime"
+	"github.com/powerslider/ethereum-validator-api/pkg/proposerduties"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock uses the mainnet genesis time and spec.
+var testClock = chaintime.NewClock(time.Unix(1606824023, 0), 12, 32, 256)
+
+// mockBeaconService answers with the proposers of the first three slots, out of slot order.
+type mockBeaconService struct{}
+
+func (mockBeaconService) GetProposerDuties(ctx context.Context, epoch uint64) (*beacon.ProposerDutiesResponse, error) {
+	start := epoch * 32
+
+	return &beacon.ProposerDutiesResponse{
+		DependentRoot: "0xdependent",
+		Data: []beacon.ProposerDuty{
+			{ValidatorIndex: "42", Pubkey: "0xAB42", Slot: start + 2},
+			{ValidatorIndex: "7", Pubkey: "0xab07", Slot: start + 1},
+			{ValidatorIndex: "42", Pubkey: "0xAB42", Slot: start},
+		},
+	}, nil
+}
+
+func TestGetProposerDuties(t *testing.T) {
+	t.Parallel()
+
+	svc := proposerduties.NewService(mockBeaconService{}, testClock)
+	current := testClock.EpochOf(testClock.CurrentSlot())
+
+	t.Run("all proposers in slot order", func(t *testing.T) {
+		t.Parallel()
+
+		duties, err := svc.GetProposerDuties(context.Background(), 10, nil)
+		require.NoError(t, err)
+		require.Len(t, duties.Proposers, 3)
+		assert.Equal(t, "0xdependent", duties.DependentRoot)
+		assert.Equal(t, []uint64{320, 321, 322}, []uint64{
+			duties.Proposers[0].Slot, duties.Proposers[1].Slot, duties.Proposers[2].Slot,
+		})
+	})
+
+	t.Run("filtered by index or public key", func(t *testing.T) {
+		t.Parallel()
+
+		duties, err := svc.GetProposerDuties(context.Background(), current+1, []beacon.ValidatorID{"0xab42"})
+		require.NoError(t, err)
+		require.Len(t, duties.Proposers, 2)
+		assert.Equal(t, "42", duties.Proposers[0].ValidatorIndex)
+
+		duties, err = svc.GetProposerDuties(context.Background(), 10, []beacon.ValidatorID{"7", "8"})
+		require.NoError(t, err)
+		require.Len(t, duties.Proposers, 1)
+		assert.Equal(t, uint64(321), duties.Proposers[0].Slot)
+	})
+
+	t.Run("beyond lookahead", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := svc.GetProposerDuties(context.Background(), current+2, nil)
+		require.ErrorIs(t, err, proposerduties.ErrEpochBeyondLookahead)
+	})
+}
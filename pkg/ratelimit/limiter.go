@@ -0,0 +1,259 @@
+package ratelimit
+
+import (
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/cache"
+	"golang.org/x/time/rate"
+)
+
+// ErrRateLimited is returned for requests over the rate limit of their client.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// DefaultKeyHeader is the header identifying API clients by key.
+const DefaultKeyHeader = "X-API-Key"
+
+// DefaultMaxBuckets is the default number of client and route buckets kept.
+const DefaultMaxBuckets = 100_000
+
+// idleTimeout is how long the bucket of a client is kept after its last request.
+// A bucket idle for that long is full again anyway.
+const idleTimeout = 10 * time.Minute
+
+// Limit is a token bucket refilled with RPS tokens per second, holding up to Burst tokens.
+// A zero RPS disables rate limiting.
+type Limit struct {
+	RPS   float64
+	Burst int
+}
+
+// Limiter rate limits requests per client and route with token buckets. Clients are identified
+// by their API key when it is one of Keys, by their IP address otherwise.
+type Limiter struct {
+	// Now returns the current time; time.Now when nil. Tests override it.
+	Now func() time.Time
+	// Routes overrides Default for the routes with the given templates, e.g. /api/v1/blockreward/{id}.
+	Routes map[string]Limit
+	// Keys are the API keys clients are identified by. Other keys are ignored, so that clients
+	// cannot get a fresh bucket by sending a new key.
+	Keys map[string]bool
+	// buckets holds the most recently used buckets; the least recently used one is dropped when full.
+	buckets *cache.LRU
+	// KeyHeader is the header carrying the API key (DefaultKeyHeader when empty).
+	KeyHeader string
+	// TrustedProxies are the reverse proxies whose X-Forwarded-For entries are believed. The client
+	// is the rightmost forwarded address that is not a trusted proxy.
+	TrustedProxies []netip.Prefix
+	Default        Limit
+	mu             sync.Mutex
+}
+
+// NewLimiter creates a limiter applying routes to the given route templates and def to all others,
+// keeping the buckets of at most maxBuckets clients and routes (DefaultMaxBuckets when zero).
+func NewLimiter(def Limit, routes map[string]Limit, maxBuckets int) *Limiter {
+	if maxBuckets <= 0 {
+		maxBuckets = DefaultMaxBuckets
+	}
+
+	return &Limiter{
+		Default:   def,
+		Routes:    routes,
+		KeyHeader: DefaultKeyHeader,
+		buckets:   cache.NewLRU(maxBuckets),
+	}
+}
+
+// Allow takes a token from the bucket of client on route. When the bucket is empty it returns
+// ErrRateLimited and how long the client has to wait for the next token.
+func (l *Limiter) Allow(route, client string) (time.Duration, error) {
+	limit, ok := l.Routes[route]
+	if !ok {
+		limit = l.Default
+	}
+
+	if limit.RPS <= 0 {
+		return 0, nil
+	}
+
+	now := l.now()
+	key := route + " " + client
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limiter, ok := l.bucket(key)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(limit.RPS), max(limit.Burst, 1))
+	}
+
+	// Every request refreshes the idle timeout of the bucket.
+	l.buckets.Set(key, limiter, idleTimeout)
+
+	reservation := limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		// The token is not taken, rejected requests do not delay the client further.
+		reservation.CancelAt(now)
+
+		return delay, pkgerrors.Wrapf(ErrRateLimited, "%g requests per second", limit.RPS)
+	}
+
+	return 0, nil
+}
+
+// bucket returns the token bucket stored under key. l.mu must be held.
+func (l *Limiter) bucket(key string) (*rate.Limiter, bool) {
+	value, ok := l.buckets.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	limiter, ok := value.(*rate.Limiter)
+
+	return limiter, ok
+}
+
+// Client identifies the client of r: its API key when it is a known one, its IP address otherwise.
+func (l *Limiter) Client(r *http.Request) string {
+	header := l.KeyHeader
+	if header == "" {
+		header = DefaultKeyHeader
+	}
+
+	if key := r.Header.Get(header); key != "" && l.Keys[key] {
+		return "key:" + key
+	}
+
+	return "ip:" + l.clientIP(r)
+}
+
+// clientIP returns the address r was sent from. Behind trusted proxies, it is the rightmost
+// X-Forwarded-For address that is not a trusted proxy: entries left of it may be forged by the client.
+func (l *Limiter) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	if !l.isTrustedProxy(host) {
+		return host
+	}
+
+	forwarded := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addr := strings.TrimSpace(forwarded[i])
+		if addr == "" {
+			continue
+		}
+
+		if !l.isTrustedProxy(addr) {
+			return addr
+		}
+
+		host = addr
+	}
+
+	// Every hop is a trusted proxy; the leftmost one is closest to the client.
+	return host
+}
+
+func (l *Limiter) isTrustedProxy(host string) bool {
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+
+	for _, prefix := range l.TrustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *Limiter) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+
+	return time.Now()
+}
+
+// RetryAfter formats delay as the value of a Retry-After header, in whole seconds rounded up.
+func RetryAfter(delay time.Duration) string {
+	return strconv.Itoa(max(int(math.Ceil(delay.Seconds())), 1))
+}
+
+// ParseRouteLimits parses "template=rps:burst" pairs (semicolon separated in the environment),
+// e.g. "/api/v1/blockreward/{id}=2:10".
+func ParseRouteLimits(pairs []string) (map[string]Limit, error) {
+	limits := make(map[string]Limit, len(pairs))
+
+	for _, pair := range pairs {
+		route, spec, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(route) == "" {
+			return nil, pkgerrors.Errorf("invalid route limit %q, expected template=rps:burst", pair)
+		}
+
+		rpsStr, burstStr, ok := strings.Cut(spec, ":")
+		if !ok {
+			return nil, pkgerrors.Errorf("invalid route limit %q, expected template=rps:burst", pair)
+		}
+
+		rps, err := strconv.ParseFloat(strings.TrimSpace(rpsStr), 64)
+		if err != nil || rps < 0 {
+			return nil, pkgerrors.Errorf("invalid rate in route limit %q", pair)
+		}
+
+		burst, err := strconv.Atoi(strings.TrimSpace(burstStr))
+		if err != nil || burst < 0 {
+			return nil, pkgerrors.Errorf("invalid burst in route limit %q", pair)
+		}
+
+		limits[strings.TrimSpace(route)] = Limit{RPS: rps, Burst: burst}
+	}
+
+	return limits, nil
+}
+
+// ParseTrustedProxies parses IP addresses and CIDR ranges, e.g. "10.0.0.0/8" or "192.0.2.1".
+func ParseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+
+		if strings.Contains(value, "/") {
+			prefix, err := netip.ParsePrefix(value)
+			if err != nil {
+				return nil, pkgerrors.Wrapf(err, "invalid trusted proxy range %q", value)
+			}
+
+			prefixes = append(prefixes, prefix.Masked())
+
+			continue
+		}
+
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "invalid trusted proxy address %q", value)
+		}
+
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+
+	return prefixes, nil
+}
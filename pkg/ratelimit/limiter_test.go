@@ -0,0 +1,163 @@
+package ratelimit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/powerslider/ethereum-validator-api/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+
+	limiter := ratelimit.NewLimiter(ratelimit.Limit{RPS: 1, Burst: 2}, map[string]ratelimit.Limit{
+		"/api/v1/blockreward": {RPS: 0.1, Burst: 1},
+		"/healthz":            {},
+	}, 0)
+	limiter.Now = func() time.Time { return now }
+
+	t.Run("default limit", func(t *testing.T) {
+		for range 2 {
+			_, err := limiter.Allow("/api/v1/blockreward/{id}", "ip:10.0.0.1")
+			require.NoError(t, err)
+		}
+
+		delay, err := limiter.Allow("/api/v1/blockreward/{id}", "ip:10.0.0.1")
+		require.ErrorIs(t, err, ratelimit.ErrRateLimited)
+		assert.Equal(t, time.Second, delay)
+
+		// Other clients have their own bucket.
+		_, err = limiter.Allow("/api/v1/blockreward/{id}", "ip:10.0.0.2")
+		require.NoError(t, err)
+	})
+
+	t.Run("route override", func(t *testing.T) {
+		_, err := limiter.Allow("/api/v1/blockreward", "key:abc")
+		require.NoError(t, err)
+
+		delay, err := limiter.Allow("/api/v1/blockreward", "key:abc")
+		require.ErrorIs(t, err, ratelimit.ErrRateLimited)
+		assert.Equal(t, 10*time.Second, delay)
+		assert.Equal(t, "10", ratelimit.RetryAfter(delay))
+	})
+
+	t.Run("unlimited route", func(t *testing.T) {
+		for range 100 {
+			_, err := limiter.Allow("/healthz", "ip:10.0.0.1")
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("rejected requests do not delay the client further", func(t *testing.T) {
+		for range 2 {
+			_, err := limiter.Allow("/api/v1/syncduties/{id}", "ip:10.0.0.3")
+			require.NoError(t, err)
+		}
+
+		for range 5 {
+			_, err := limiter.Allow("/api/v1/syncduties/{id}", "ip:10.0.0.3")
+			require.ErrorIs(t, err, ratelimit.ErrRateLimited)
+		}
+
+		now = now.Add(time.Second)
+
+		_, err := limiter.Allow("/api/v1/syncduties/{id}", "ip:10.0.0.3")
+		require.NoError(t, err)
+	})
+}
+
+func TestBucketsAreBounded(t *testing.T) {
+	t.Parallel()
+
+	limiter := ratelimit.NewLimiter(ratelimit.Limit{RPS: 1, Burst: 1}, nil, 2)
+
+	_, err := limiter.Allow("/a", "ip:10.0.0.1")
+	require.NoError(t, err)
+
+	// Two other clients push the first one's empty bucket out.
+	for _, client := range []string{"ip:10.0.0.2", "ip:10.0.0.3"} {
+		_, err = limiter.Allow("/a", client)
+		require.NoError(t, err)
+	}
+
+	_, err = limiter.Allow("/a", "ip:10.0.0.1")
+	require.NoError(t, err)
+
+	_, err = limiter.Allow("/a", "ip:10.0.0.1")
+	require.ErrorIs(t, err, ratelimit.ErrRateLimited)
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	proxies, err := ratelimit.ParseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.1"})
+	require.NoError(t, err)
+
+	limiter := ratelimit.NewLimiter(ratelimit.Limit{}, nil, 0)
+	limiter.Keys = map[string]bool{"known": true}
+
+	newRequest := func(remoteAddr, forwardedFor, key string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/blockreward/head", nil)
+		req.RemoteAddr = remoteAddr
+
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+
+		if key != "" {
+			req.Header.Set(ratelimit.DefaultKeyHeader, key)
+		}
+
+		return req
+	}
+
+	t.Run("API keys", func(t *testing.T) {
+		assert.Equal(t, "key:known", limiter.Client(newRequest("198.51.100.1:52000", "", "known")))
+		// Unknown keys do not get their own bucket.
+		assert.Equal(t, "ip:198.51.100.1", limiter.Client(newRequest("198.51.100.1:52000", "", "random")))
+	})
+
+	t.Run("untrusted forwarded for", func(t *testing.T) {
+		assert.Equal(t, "ip:10.0.0.1", limiter.Client(newRequest("10.0.0.1:52000", "203.0.113.7", "")))
+	})
+
+	limiter.TrustedProxies = proxies
+
+	t.Run("trusted proxies", func(t *testing.T) {
+		// The leftmost entry is forged by the client; the rightmost untrusted one was seen by the proxy.
+		req := newRequest("10.0.0.1:52000", "1.2.3.4, 203.0.113.7, 10.0.0.254", "")
+		assert.Equal(t, "ip:203.0.113.7", limiter.Client(req))
+
+		req = newRequest("192.0.2.1:52000", "203.0.113.7", "")
+		assert.Equal(t, "ip:203.0.113.7", limiter.Client(req))
+
+		// Clients connecting directly cannot choose their address.
+		req = newRequest("198.51.100.1:52000", "203.0.113.7", "")
+		assert.Equal(t, "ip:198.51.100.1", limiter.Client(req))
+	})
+}
+
+func TestParseRouteLimits(t *testing.T) {
+	t.Parallel()
+
+	limits, err := ratelimit.ParseRouteLimits([]string{"/api/v1/blockreward=0.2:2", " /healthz = 0:0 "})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ratelimit.Limit{
+		"/api/v1/blockreward": {RPS: 0.2, Burst: 2},
+		"/healthz":            {},
+	}, limits)
+
+	for _, invalid := range []string{"/api/v1/blockreward", "=1:1", "/a=1", "/a=x:1", "/a=1:-1"} {
+		_, err = ratelimit.ParseRouteLimits([]string{invalid})
+		require.Error(t, err, invalid)
+	}
+
+	_, err = ratelimit.ParseTrustedProxies([]string{"10.0.0.0/33"})
+	require.Error(t, err)
+}
@@ -0,0 +1,205 @@
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+var (
+	ErrPayloadNotDelivered = errors.New("no relay delivered a payload for the slot")
+	ErrRelaysUnavailable   = errors.New("no relay could be queried")
+	ErrRelaysIncomplete    = errors.New("not every relay could be queried")
+)
+
+// Relay is a MEV-Boost relay exposing the relay data API.
+type Relay struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// DeliveredBid is the bid a relay delivered to the proposer of a slot.
+type DeliveredBid struct {
+	// Value is the bid value paid to the proposer, in Wei.
+	Value         *big.Int
+	BlockHash     string
+	BuilderPubkey string
+	// Relays lists the names of all relays that delivered this payload.
+	Relays []string
+	Slot   uint64
+}
+
+// Service queries a set of MEV-Boost relays for delivered payloads.
+type Service struct {
+	HTTPClient *http.Client
+	Relays     []Relay
+}
+
+// NewService creates a new relay service for the given relay URLs. Relays are
+// named after their host (and port, if any). When client is nil, http.DefaultClient is used.
+func NewService(relayURLs []string, client *http.Client) (*Service, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	relays := make([]Relay, 0, len(relayURLs))
+
+	for _, rawURL := range relayURLs {
+		u, err := url.Parse(strings.TrimRight(rawURL, "/"))
+		if err != nil || u.Host == "" {
+			return nil, pkgerrors.Errorf("invalid relay URL %q", rawURL)
+		}
+
+		// Relay URLs commonly embed the relay public key as user info, which is not part of the API.
+		u.User = nil
+
+		relays = append(relays, Relay{Name: u.Host, URL: u.String()})
+	}
+
+	return &Service{
+		HTTPClient: client,
+		Relays:     relays,
+	}, nil
+}
+
+// GetDeliveredBid queries all relays concurrently for the payload delivered at slot.
+// When blockHash is not empty, bids for other blocks are ignored. Relays that fail are
+// skipped as long as another relay delivered the payload. Otherwise ErrRelaysUnavailable is
+// returned when none of them could be queried, ErrRelaysIncomplete when only some of them
+// could, since the payload may have come from a failed one, and ErrPayloadNotDelivered when
+// all relays answered without a matching payload.
+func (s *Service) GetDeliveredBid(ctx context.Context, slot uint64, blockHash string) (*DeliveredBid, error) {
+	if len(s.Relays) == 0 {
+		return nil, pkgerrors.Wrap(ErrRelaysUnavailable, "no relays configured")
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		bid      *DeliveredBid
+		failures []string
+	)
+
+	for _, r := range s.Relays {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			traces, err := s.fetchDelivered(ctx, r, slot)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", r.Name, err))
+				return
+			}
+
+			for _, trace := range traces {
+				if trace.Slot != slot || (blockHash != "" && !strings.EqualFold(trace.BlockHash, blockHash)) {
+					continue
+				}
+
+				if bid == nil {
+					value, ok := new(big.Int).SetString(trace.Value, 10)
+					if !ok {
+						failures = append(failures, fmt.Sprintf("%s: invalid bid value %q", r.Name, trace.Value))
+						return
+					}
+
+					bid = &DeliveredBid{
+						Slot:          trace.Slot,
+						BlockHash:     trace.BlockHash,
+						BuilderPubkey: trace.BuilderPubkey,
+						Value:         value,
+					}
+				}
+
+				bid.Relays = append(bid.Relays, r.Name)
+
+				break
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if bid != nil {
+		// Keep the relay order stable regardless of which relay answered first.
+		bid.Relays = s.sortByConfigOrder(bid.Relays)
+		return bid, nil
+	}
+
+	if len(failures) == len(s.Relays) {
+		return nil, pkgerrors.Wrap(ErrRelaysUnavailable, strings.Join(failures, "; "))
+	}
+
+	if len(failures) > 0 {
+		return nil, pkgerrors.Wrap(ErrRelaysIncomplete, strings.Join(failures, "; "))
+	}
+
+	return nil, pkgerrors.Wrapf(ErrPayloadNotDelivered, "slot %d", slot)
+}
+
+// fetchDelivered fetches the payloads a single relay delivered at slot.
+func (s *Service) fetchDelivered(ctx context.Context, r Relay, slot uint64) ([]BidTrace, error) {
+	endpoint := fmt.Sprintf("%s/relay/v1/data/bidtraces/proposer_payload_delivered?slot=%d", r.URL, slot)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "create relay request")
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "execute relay request")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "read relay response body")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, pkgerrors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var traces []BidTrace
+	if err = json.Unmarshal(body, &traces); err != nil {
+		return nil, pkgerrors.Wrap(err, "parse relay response")
+	}
+
+	return traces, nil
+}
+
+// sortByConfigOrder orders relay names as they appear in the configuration.
+func (s *Service) sortByConfigOrder(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		seen[name] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+
+	for _, r := range s.Relays {
+		if seen[r.Name] {
+			sorted = append(sorted, r.Name)
+			delete(seen, r.Name)
+		}
+	}
+
+	return sorted
+}
@@ -0,0 +1,131 @@
+package relay_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/powerslider/ethereum-validator-api/pkg/relay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	deliveredSlot = 9000000
+	blockHash     = "0xb10c"
+)
+
+// newFakeRelay starts a relay data API that delivered blockHash at deliveredSlot.
+func newFakeRelay(t *testing.T, value string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/relay/v1/data/bidtraces/proposer_payload_delivered" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.Query().Get("slot") != fmt.Sprint(deliveredSlot) {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `[{"slot":"%d","block_hash":%q,"builder_pubkey":"0xbuilder","value":%q,
+			"parent_hash":"0x01","proposer_pubkey":"0xproposer","proposer_fee_recipient":"0xfee",
+			"gas_limit":"30000000","gas_used":"15000000","block_number":"100","num_tx":"150"}]`,
+			deliveredSlot, blockHash, value)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// newDownRelay starts a relay that fails every request.
+func newDownRelay(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestGetDeliveredBid(t *testing.T) {
+	t.Parallel()
+
+	t.Run("tolerates relays being down", func(t *testing.T) {
+		t.Parallel()
+
+		down := newDownRelay(t)
+		up := newFakeRelay(t, "42000000000000000")
+
+		svc, err := relay.NewService([]string{down.URL, up.URL}, nil)
+		require.NoError(t, err)
+
+		bid, err := svc.GetDeliveredBid(context.Background(), deliveredSlot, blockHash)
+		require.NoError(t, err)
+
+		assert.Equal(t, "0xbuilder", bid.BuilderPubkey)
+		assert.Equal(t, "42000000000000000", bid.Value.String())
+		assert.Equal(t, []string{svc.Relays[1].Name}, bid.Relays)
+	})
+
+	t.Run("collects all delivering relays", func(t *testing.T) {
+		t.Parallel()
+
+		first := newFakeRelay(t, "1")
+		second := newFakeRelay(t, "1")
+
+		svc, err := relay.NewService([]string{first.URL, second.URL}, nil)
+		require.NoError(t, err)
+
+		bid, err := svc.GetDeliveredBid(context.Background(), deliveredSlot, "")
+		require.NoError(t, err)
+		assert.Len(t, bid.Relays, 2)
+	})
+
+	t.Run("ignores bids for other blocks", func(t *testing.T) {
+		t.Parallel()
+
+		svc, err := relay.NewService([]string{newFakeRelay(t, "1").URL}, nil)
+		require.NoError(t, err)
+
+		_, err = svc.GetDeliveredBid(context.Background(), deliveredSlot, "0xother")
+		require.ErrorIs(t, err, relay.ErrPayloadNotDelivered)
+	})
+
+	t.Run("not delivered", func(t *testing.T) {
+		t.Parallel()
+
+		svc, err := relay.NewService([]string{newFakeRelay(t, "1").URL, newFakeRelay(t, "1").URL}, nil)
+		require.NoError(t, err)
+
+		_, err = svc.GetDeliveredBid(context.Background(), deliveredSlot+1, "")
+		require.ErrorIs(t, err, relay.ErrPayloadNotDelivered)
+	})
+
+	t.Run("not delivered by the relays that answered", func(t *testing.T) {
+		t.Parallel()
+
+		svc, err := relay.NewService([]string{newFakeRelay(t, "1").URL, newDownRelay(t).URL}, nil)
+		require.NoError(t, err)
+
+		// The relay that is down may have delivered the payload.
+		_, err = svc.GetDeliveredBid(context.Background(), deliveredSlot+1, "")
+		require.ErrorIs(t, err, relay.ErrRelaysIncomplete)
+	})
+
+	t.Run("all relays down", func(t *testing.T) {
+		t.Parallel()
+
+		svc, err := relay.NewService([]string{newDownRelay(t).URL, newDownRelay(t).URL}, nil)
+		require.NoError(t, err)
+
+		_, err = svc.GetDeliveredBid(context.Background(), deliveredSlot, "")
+		require.ErrorIs(t, err, relay.ErrRelaysUnavailable)
+	})
+}
@@ -0,0 +1,17 @@
+package relay
+
+// BidTrace is an entry of /relay/v1/data/bidtraces/proposer_payload_delivered.
+type BidTrace struct {
+	ParentHash           string `json:"parent_hash"`
+	BlockHash            string `json:"block_hash"`
+	BuilderPubkey        string `json:"builder_pubkey"`
+	ProposerPubkey       string `json:"proposer_pubkey"`
+	ProposerFeeRecipient string `json:"proposer_fee_recipient"`
+	// Value is the bid value paid to the proposer, in Wei.
+	Value       string `json:"value"`
+	Slot        uint64 `json:"slot,string"`
+	GasLimit    uint64 `json:"gas_limit,string"`
+	GasUsed     uint64 `json:"gas_used,string"`
+	BlockNumber uint64 `json:"block_number,string"`
+	NumTx       uint64 `json:"num_tx,string"`
+}
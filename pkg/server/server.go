@@ -15,14 +15,18 @@ import (
 	"github.com/gorilla/mux"
 	pkgerrors "github.com/pkg/errors"
 	"github.com/powerslider/ethereum-validator-api/pkg/config"
+	"github.com/powerslider/ethereum-validator-api/pkg/metrics"
 )
 
 // Server represents an HTTP server.
 type Server struct {
 	srv *http.Server
+	// metricsSrv serves the metrics on their own port, nil when they are served by srv.
+	metricsSrv *http.Server
 }
 
 // NewServer constructs new HTTP server with the provided muxer.
+// Prometheus metrics are served at config.MetricsPath, on config.MetricsPort when set.
 func NewServer(
 	config *config.Config,
 	muxer *mux.Router,
@@ -33,13 +37,39 @@ func NewServer(
 		Handler:           muxer,
 	}
 
+	if config.MetricsPort == 0 {
+		muxer.Handle(config.MetricsPath, metrics.Handler()).Methods("GET")
+
+		return &Server{
+			srv: server,
+		}
+	}
+
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle(config.MetricsPath, metrics.Handler())
+
 	return &Server{
 		srv: server,
+		metricsSrv: &http.Server{
+			Addr:              net.JoinHostPort(config.ServerHost, strconv.Itoa(config.MetricsPort)),
+			ReadHeaderTimeout: 10 * time.Second,
+			Handler:           metricsMux,
+		},
 	}
 }
 
+// RegisterOnShutdown registers a function to call when the server shuts down, e.g. to end
+// long-lived streaming responses that would otherwise hold up the shutdown.
+func (s *Server) RegisterOnShutdown(f func()) {
+	s.srv.RegisterOnShutdown(f)
+}
+
 // Start starts the HTTP server.
 func (s *Server) Start(errChan chan error) {
+	if s.metricsSrv != nil {
+		go s.startMetrics(errChan)
+	}
+
 	log.Printf("[Start] HTTP server is starting on %s:\n", s.srv.Addr)
 
 	err := s.srv.ListenAndServe()
@@ -48,11 +78,22 @@ func (s *Server) Start(errChan chan error) {
 	}
 }
 
-// Stop stops the HTTP server.
+// startMetrics starts the metrics HTTP server.
+func (s *Server) startMetrics(errChan chan error) {
+	log.Printf("[Start] Metrics server is starting on %s:\n", s.metricsSrv.Addr)
+
+	err := s.metricsSrv.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		errChan <- pkgerrors.WithStack(err)
+	}
+}
+
+// Stop stops the HTTP server, waiting for in-flight requests to finish. The shutdown gets its
+// grace period even when ctx is canceled, e.g. by a function registered with RegisterOnShutdown.
 func (s *Server) Stop(ctx context.Context) error {
 	log.Println("[Shutdown] HTTP srv is shutting down...")
 
-	shutdownCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second*5)
 	defer cancel()
 
 	err := s.srv.Shutdown(shutdownCtx)
@@ -60,12 +101,18 @@ func (s *Server) Stop(ctx context.Context) error {
 		return pkgerrors.Wrap(pkgerrors.WithStack(err), "shutdown server")
 	}
 
+	if s.metricsSrv != nil {
+		if err = s.metricsSrv.Shutdown(shutdownCtx); err != nil {
+			return pkgerrors.Wrap(pkgerrors.WithStack(err), "shutdown metrics server")
+		}
+	}
+
 	return nil
 }
 
 // Run manages the HTTP server lifecycle on start and on shutdown.
 func (s *Server) Run(ctx context.Context) error {
-	errChan := make(chan error, 1) // Buffered, avoid possible blocking.
+	errChan := make(chan error, 2) // Buffered for both listeners, avoid possible blocking.
 
 	go s.Start(errChan)
 
@@ -0,0 +1,90 @@
+package server_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/powerslider/ethereum-validator-api/pkg/config"
+	"github.com/powerslider/ethereum-validator-api/pkg/server"
+	"github.com/stretchr/testify/require"
+)
+
+// freePort returns a port that is free to listen on.
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+
+	return port
+}
+
+// TestStopWithStreamConnected shuts the server down while a client holds a streaming response
+// open, which only ends when a shutdown hook cancels the context the server was started with.
+func TestStopWithStreamConnected(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := mux.NewRouter()
+	r.HandleFunc("/stream", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = http.NewResponseController(w).Flush()
+
+		<-ctx.Done()
+	})
+
+	cfg := &config.Config{
+		ServerHost:  "127.0.0.1",
+		ServerPort:  freePort(t),
+		MetricsPort: freePort(t),
+		MetricsPath: "/metrics",
+	}
+
+	srv := server.NewServer(cfg, r)
+	srv.RegisterOnShutdown(cancel)
+
+	errChan := make(chan error, 2)
+	go srv.Start(errChan)
+
+	url := "http://" + net.JoinHostPort(cfg.ServerHost, strconv.Itoa(cfg.ServerPort)) + "/stream"
+
+	var resp *http.Response
+
+	require.Eventually(t, func() bool {
+		var err error
+
+		resp, err = http.Get(url) //nolint:noctx // the stream is ended by the server
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The stream ends once the shutdown hook runs; the server waits for it and stops cleanly.
+	done := make(chan error, 1)
+	go func() {
+		_, err := bufio.NewReader(resp.Body).ReadByte()
+		done <- err
+	}()
+
+	require.NoError(t, srv.Stop(ctx))
+	require.Error(t, <-done)
+
+	select {
+	case err := <-errChan:
+		require.NoError(t, err)
+	default:
+	}
+}
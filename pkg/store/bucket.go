@@ -0,0 +1,120 @@
+package store
+
+import (
+	"errors"
+	"log"
+
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+)
+
+// Backend is the storage a Bucket reads and writes. It is satisfied by *Store.
+type Backend interface {
+	Get(bucket string, slot uint64, v any) (Meta, error)
+	GetByRoot(bucket, root string, v any) (Meta, error)
+	GetCanonical(bucket string, slot uint64, root string, v any) (Meta, error)
+	Put(bucket string, meta Meta, v any) error
+	DeleteFrom(bucket string, fromSlot uint64) (int, error)
+}
+
+// Bucket gives typed access to the values of type T computed per block and stored in the named
+// bucket of Backend. A nil Backend disables it: nothing is loaded and nothing is saved.
+//
+// The store only saves upstream round trips, so its failures are logged and never returned.
+type Bucket[T any] struct {
+	Backend Backend
+	// MarkFinalized updates a value computed before its block was finalized, when it is loaded
+	// for the finalized block. Optional.
+	MarkFinalized func(v *T)
+	Name          string
+}
+
+// LoadFinalized returns the value stored for a slot or block root when it is finalized
+// and can be served without going upstream, nil otherwise.
+func (b Bucket[T]) LoadFinalized(id beacon.BlockID) *T {
+	if b.Backend == nil {
+		return nil
+	}
+
+	var (
+		v    T
+		meta Meta
+		err  error
+	)
+
+	// Named blocks such as "head" move, so they are resolved upstream first.
+	if slot, ok := id.Slot(); ok {
+		meta, err = b.Backend.Get(b.Name, slot, &v)
+	} else if id.IsRoot() {
+		meta, err = b.Backend.GetByRoot(b.Name, string(id), &v)
+	} else {
+		return nil
+	}
+
+	if err != nil {
+		logError(err)
+		return nil
+	}
+
+	if !meta.Finalized {
+		return nil
+	}
+
+	return &v
+}
+
+// LoadCanonical returns the value stored for the resolved block when it was computed for the
+// canonical block root, nil otherwise. A value stored for a reorged block is discarded.
+func (b Bucket[T]) LoadCanonical(block Meta) *T {
+	if b.Backend == nil {
+		return nil
+	}
+
+	var v T
+
+	meta, err := b.Backend.GetCanonical(b.Name, block.Slot, block.Root, &v)
+	if err != nil {
+		logError(err)
+		return nil
+	}
+
+	// The block was finalized since the value was stored.
+	if block.Finalized && !meta.Finalized {
+		if b.MarkFinalized != nil {
+			b.MarkFinalized(&v)
+		}
+
+		b.Save(block, &v)
+	}
+
+	return &v
+}
+
+// Save stores the value computed for block.
+func (b Bucket[T]) Save(block Meta, v *T) {
+	if b.Backend == nil {
+		return
+	}
+
+	if err := b.Backend.Put(b.Name, block, v); err != nil {
+		logError(err)
+	}
+}
+
+// InvalidateFrom discards the stored non-finalized values of fromSlot and later slots,
+// which may have been computed for blocks reorged out.
+func (b Bucket[T]) InvalidateFrom(fromSlot uint64) {
+	if b.Backend == nil {
+		return
+	}
+
+	if _, err := b.Backend.DeleteFrom(b.Name, fromSlot); err != nil {
+		logError(err)
+	}
+}
+
+// logError logs a store failure other than a missing value.
+func logError(err error) {
+	if !errors.Is(err, ErrNotFound) {
+		log.Printf("[Store] %v", err)
+	}
+}
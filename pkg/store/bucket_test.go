@@ -0,0 +1,66 @@
+package store_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type finalizable struct {
+	Name      string
+	Finalized bool
+}
+
+func TestBucket(t *testing.T) {
+	t.Parallel()
+
+	st, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = st.Close() })
+
+	bucket := store.Bucket[finalizable]{
+		Backend:       st,
+		Name:          "bucket",
+		MarkFinalized: func(v *finalizable) { v.Finalized = true },
+	}
+
+	bucket.Save(store.Meta{Slot: 100, Root: "0xa"}, &finalizable{Name: "a"})
+
+	// Not finalized yet: only served once the block is resolved upstream.
+	assert.Nil(t, bucket.LoadFinalized(beacon.SlotBlockID(100)))
+	assert.Nil(t, bucket.LoadCanonical(store.Meta{Slot: 100, Root: "0xb"}))
+
+	bucket.Save(store.Meta{Slot: 100, Root: "0xa"}, &finalizable{Name: "a"})
+	assert.Equal(t, &finalizable{Name: "a"}, bucket.LoadCanonical(store.Meta{Slot: 100, Root: "0xa"}))
+
+	// Loaded for the finalized block, the value is finalized and stored as such.
+	assert.Equal(t, &finalizable{Name: "a", Finalized: true},
+		bucket.LoadCanonical(store.Meta{Slot: 100, Root: "0xa", Finalized: true}))
+
+	for _, id := range []beacon.BlockID{beacon.SlotBlockID(100), "0xa"} {
+		assert.Equal(t, &finalizable{Name: "a", Finalized: true}, bucket.LoadFinalized(id), id)
+	}
+
+	// Named blocks move, they are never served from the store.
+	assert.Nil(t, bucket.LoadFinalized("head"))
+
+	// Finalized values survive reorgs.
+	bucket.InvalidateFrom(0)
+	assert.NotNil(t, bucket.LoadFinalized(beacon.SlotBlockID(100)))
+}
+
+func TestBucketWithoutBackend(t *testing.T) {
+	t.Parallel()
+
+	bucket := store.Bucket[finalizable]{Name: "bucket"}
+
+	bucket.Save(store.Meta{Slot: 100, Root: "0xa", Finalized: true}, &finalizable{Name: "a"})
+	bucket.InvalidateFrom(100)
+
+	assert.Nil(t, bucket.LoadFinalized(beacon.SlotBlockID(100)))
+	assert.Nil(t, bucket.LoadCanonical(store.Meta{Slot: 100, Root: "0xa"}))
+}
@@ -0,0 +1,312 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+var ErrNotFound = errors.New("not found in store")
+
+// Meta identifies the block a stored value was computed for.
+type Meta struct {
+	// Root is the block root at Slot, empty for missed slots.
+	Root string `json:"root"`
+	Slot uint64 `json:"slot"`
+	// Finalized values can never be invalidated by a reorg.
+	Finalized bool `json:"finalized"`
+}
+
+// record is the stored representation of a value.
+type record struct {
+	Value json.RawMessage `json:"value"`
+	Meta
+}
+
+// Store persists computed values on disk in named buckets, keyed by slot and indexed by block root.
+// It is safe for concurrent use.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens the store at path, creating the file and its directory if needed.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, pkgerrors.Wrap(err, "create store directory")
+	}
+
+	// The file is locked while open; fail instead of waiting forever on another process holding it.
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "open store %s", path)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database file.
+func (s *Store) Close() error {
+	return pkgerrors.Wrap(s.db.Close(), "close store")
+}
+
+// Get decodes the value stored for slot in bucket into v. Returns ErrNotFound when there is none.
+func (s *Store) Get(bucket string, slot uint64, v any) (Meta, error) {
+	var rec record
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return ErrNotFound
+		}
+
+		return decode(b.Get(slotKey(slot)), &rec, v)
+	})
+	if err != nil {
+		return Meta{}, pkgerrors.Wrapf(err, "get %s for slot %d", bucket, slot)
+	}
+
+	return rec.Meta, nil
+}
+
+// GetByRoot decodes the value stored for the block root in bucket into v.
+// Returns ErrNotFound when there is none.
+func (s *Store) GetByRoot(bucket, root string, v any) (Meta, error) {
+	var rec record
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return ErrNotFound
+		}
+
+		key := b.Bucket(rootsBucket).Get([]byte(root))
+		if key == nil {
+			return ErrNotFound
+		}
+
+		return decode(b.Get(key), &rec, v)
+	})
+	if err != nil {
+		return Meta{}, pkgerrors.Wrapf(err, "get %s for root %s", bucket, root)
+	}
+
+	return rec.Meta, nil
+}
+
+// GetCanonical decodes the value stored for slot in bucket into v, provided it was computed
+// for the block root that is canonical now. A non-finalized value computed for another root
+// was reorged out: it is deleted and ErrNotFound is returned.
+func (s *Store) GetCanonical(bucket string, slot uint64, root string, v any) (Meta, error) {
+	meta, err := s.Get(bucket, slot, v)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	if meta.Root == root {
+		return meta, nil
+	}
+
+	if err = s.Delete(bucket, slot); err != nil {
+		return Meta{}, err
+	}
+
+	return Meta{}, pkgerrors.Wrapf(ErrNotFound, "%s for slot %d was computed for reorged block %s", bucket, slot, meta.Root)
+}
+
+// Put stores v for the slot and root of meta in bucket, replacing any previous value for the slot.
+func (s *Store) Put(bucket string, meta Meta, v any) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "encode %s", bucket)
+	}
+
+	data, err := json.Marshal(record{Meta: meta, Value: value})
+	if err != nil {
+		return pkgerrors.Wrapf(err, "encode %s", bucket)
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped below
+		}
+
+		roots, err := b.CreateBucketIfNotExists(rootsBucket)
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped below
+		}
+
+		key := slotKey(meta.Slot)
+		if err = deleteRoot(b, roots, key); err != nil {
+			return err
+		}
+
+		if meta.Root != "" {
+			if err = roots.Put([]byte(meta.Root), key); err != nil {
+				return err //nolint:wrapcheck // wrapped below
+			}
+		}
+
+		return b.Put(key, data) //nolint:wrapcheck // wrapped below
+	})
+
+	return pkgerrors.Wrapf(err, "put %s for slot %d", bucket, meta.Slot)
+}
+
+// Delete removes the value stored for slot in bucket, if any.
+func (s *Store) Delete(bucket string, slot uint64) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+
+		key := slotKey(slot)
+		if err := deleteRoot(b, b.Bucket(rootsBucket), key); err != nil {
+			return err
+		}
+
+		return b.Delete(key) //nolint:wrapcheck // wrapped below
+	})
+
+	return pkgerrors.Wrapf(err, "delete %s for slot %d", bucket, slot)
+}
+
+// DeleteFrom removes the non-finalized values stored in bucket for fromSlot and later slots,
+// e.g. after a reorg, and returns how many were removed.
+func (s *Store) DeleteFrom(bucket string, fromSlot uint64) (int, error) {
+	removed := 0
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+
+		var stale [][]byte
+
+		c := b.Cursor()
+		for key, data := c.Seek(slotKey(fromSlot)); key != nil; key, data = c.Next() {
+			// Nested buckets such as the root index have no value.
+			if data == nil {
+				continue
+			}
+
+			var meta Meta
+			if err := json.Unmarshal(data, &meta); err != nil {
+				return pkgerrors.Wrap(err, "decode stored record")
+			}
+
+			if !meta.Finalized {
+				stale = append(stale, key)
+			}
+		}
+
+		// Keys are deleted after iterating, deleting under a cursor skips entries.
+		roots := b.Bucket(rootsBucket)
+
+		for _, key := range stale {
+			if err := deleteRoot(b, roots, key); err != nil {
+				return err
+			}
+
+			if err := b.Delete(key); err != nil {
+				return err //nolint:wrapcheck // wrapped below
+			}
+		}
+
+		removed = len(stale)
+
+		return nil
+	})
+
+	return removed, pkgerrors.Wrapf(err, "delete %s from slot %d", bucket, fromSlot)
+}
+
+// GetProgress decodes the progress saved under name into v, e.g. the last slot a job processed.
+// Returns ErrNotFound when there is none.
+func (s *Store) GetProgress(name string, v any) error {
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(progressBucket)
+		if b == nil {
+			return ErrNotFound
+		}
+
+		data := b.Get([]byte(name))
+		if data == nil {
+			return ErrNotFound
+		}
+
+		return json.Unmarshal(data, v) //nolint:wrapcheck // wrapped below
+	})
+
+	return pkgerrors.Wrapf(err, "get progress %s", name)
+}
+
+// PutProgress saves the progress v under name.
+func (s *Store) PutProgress(name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "encode progress %s", name)
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(progressBucket)
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped below
+		}
+
+		return b.Put([]byte(name), data) //nolint:wrapcheck // wrapped below
+	})
+
+	return pkgerrors.Wrapf(err, "put progress %s", name)
+}
+
+// progressBucket holds job progress, keyed by job name.
+var progressBucket = []byte("progress")
+
+// rootsBucket is the nested bucket mapping block roots to slot keys.
+var rootsBucket = []byte("roots")
+
+// deleteRoot removes the root index entry of the value stored under key.
+func deleteRoot(b, roots *bolt.Bucket, key []byte) error {
+	data := b.Get(key)
+	if data == nil {
+		return nil
+	}
+
+	var meta Meta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return pkgerrors.Wrap(err, "decode stored record")
+	}
+
+	if meta.Root == "" {
+		return nil
+	}
+
+	return roots.Delete([]byte(meta.Root)) //nolint:wrapcheck // wrapped by the caller
+}
+
+// decode decodes a stored record and its value.
+func decode(data []byte, rec *record, v any) error {
+	if data == nil {
+		return ErrNotFound
+	}
+
+	if err := json.Unmarshal(data, rec); err != nil {
+		return pkgerrors.Wrap(err, "decode stored record")
+	}
+
+	return pkgerrors.Wrap(json.Unmarshal(rec.Value, v), "decode stored value")
+}
+
+// slotKey encodes a slot so that keys sort by slot.
+func slotKey(slot uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, slot)
+}
@@ -0,0 +1,87 @@
+package store_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/powerslider/ethereum-validator-api/pkg/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type value struct {
+	Name string
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "data", "store.db")
+
+	st, err := store.Open(path)
+	require.NoError(t, err)
+
+	require.NoError(t, st.Put("bucket", store.Meta{Slot: 100, Root: "0xa"}, value{Name: "a"}))
+
+	var got value
+
+	meta, err := st.GetByRoot("bucket", "0xa", &got)
+	require.NoError(t, err)
+	assert.Equal(t, store.Meta{Slot: 100, Root: "0xa"}, meta)
+	assert.Equal(t, "a", got.Name)
+
+	// A reorg replaced block 0xa at slot 100 with 0xb.
+	_, err = st.GetCanonical("bucket", 100, "0xb", &got)
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	_, err = st.Get("bucket", 100, &got)
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	_, err = st.GetByRoot("bucket", "0xa", &got)
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	// Values survive a restart.
+	require.NoError(t, st.Put("bucket", store.Meta{Slot: 100, Root: "0xb", Finalized: true}, value{Name: "b"}))
+	require.NoError(t, st.Close())
+
+	st, err = store.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = st.Close() })
+
+	meta, err = st.GetCanonical("bucket", 100, "0xb", &got)
+	require.NoError(t, err)
+	assert.True(t, meta.Finalized)
+	assert.Equal(t, "b", got.Name)
+}
+
+func TestStoreDeleteFrom(t *testing.T) {
+	t.Parallel()
+
+	st, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = st.Close() })
+
+	require.NoError(t, st.Put("bucket", store.Meta{Slot: 99, Root: "0x99"}, value{}))
+	require.NoError(t, st.Put("bucket", store.Meta{Slot: 100, Root: "0x100", Finalized: true}, value{}))
+	require.NoError(t, st.Put("bucket", store.Meta{Slot: 101, Root: "0x101"}, value{}))
+	require.NoError(t, st.Put("bucket", store.Meta{Slot: 102}, value{}))
+
+	removed, err := st.DeleteFrom("bucket", 100)
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	var got value
+
+	for _, slot := range []uint64{99, 100} {
+		_, err = st.Get("bucket", slot, &got)
+		require.NoError(t, err)
+	}
+
+	for _, slot := range []uint64{101, 102} {
+		_, err = st.Get("bucket", slot, &got)
+		require.ErrorIs(t, err, store.ErrNotFound)
+	}
+
+	_, err = st.GetByRoot("bucket", "0x101", &got)
+	require.ErrorIs(t, err, store.ErrNotFound)
+}
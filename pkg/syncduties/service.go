@@ -2,46 +2,175 @@ package syncduties
 
 import (
 	"context"
+	"errors"
+
 	pkgerrors "github.com/pkg/errors"
 	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/store"
+	"github.com/powerslider/ethereum-validator-api/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = tracing.Tracer("github.com/powerslider/ethereum-validator-api/pkg/syncduties")
+
 type BeaconService interface {
-	GetCurrentSlot(ctx context.Context) (uint64, error)
-	FetchSyncCommitteeIndexes(ctx context.Context, slot uint64) ([]string, error)
-	FetchValidatorsByIDs(ctx context.Context, slot uint64, ids []string) ([]string, error)
+	GetBeaconHeader(ctx context.Context, id beacon.BlockID) (*beacon.BlockHeaderResponse, error)
+	FetchSyncCommitteeIndexes(ctx context.Context, state beacon.StateID) ([]string, error)
+	FetchValidatorsByIDs(ctx context.Context, state beacon.StateID, ids []string) ([]beacon.ValidatorEntry, error)
+}
+
+// Clock tells whether a slot has started yet. It is satisfied by *chaintime.Clock.
+type Clock interface {
+	IsFuture(slot uint64) bool
+}
+
+// Member is a sync committee position and the validator assigned to it.
+// A validator can occupy several positions of the same committee.
+type Member struct {
+	ValidatorIndex string
+	Pubkey         string
+	Position       int
+}
+
+// Duties holds the sync committee of the slot a block identifier resolved to.
+type Duties struct {
+	// BlockRoot is the root of the block at Slot, empty when the slot was missed.
+	BlockRoot string
+	Members   []Member
+	Slot      uint64
+	// Finalized duties can no longer change. Others may, after a reorg replaces BlockRoot.
+	Finalized bool
 }
 
 type Service struct {
 	BeaconService BeaconService
+	Clock         Clock
+	// Store is optional; when set, computed duties are persisted and reused.
+	Store store.Backend
 }
 
-func NewService(svc BeaconService) *Service {
+func NewService(svc BeaconService, clock Clock) *Service {
 	return &Service{
 		BeaconService: svc,
+		Clock:         clock,
 	}
 }
 
-func (s *Service) GetSyncDuties(ctx context.Context, slot uint64) ([]string, error) {
-	// Step 0: Validate if slot is in the future.
-	currentSlot, err := s.BeaconService.GetCurrentSlot(ctx)
-	if err != nil {
-		return nil, pkgerrors.Wrap(err, "fetch current slot")
+// storeBucket is the store bucket holding sync committee assignments.
+const storeBucket = "syncduties"
+
+// stored returns the stored duties, disabled while Store is nil.
+func (s *Service) stored() store.Bucket[Duties] {
+	return store.Bucket[Duties]{
+		Backend:       s.Store,
+		Name:          storeBucket,
+		MarkFinalized: func(duties *Duties) { duties.Finalized = true },
 	}
+}
+
+// InvalidateFrom discards the stored non-finalized duties of fromSlot and later slots,
+// which may have been computed for blocks reorged out. It is meant to be a beacon.ReorgHook.
+func (s *Service) InvalidateFrom(fromSlot uint64) {
+	s.stored().InvalidateFrom(fromSlot)
+}
+
+// GetSyncDuties returns the sync committee members for a given block (slot, block root or
+// named block such as "head") in committee order, including validators that appear more than once.
+func (s *Service) GetSyncDuties(ctx context.Context, id beacon.BlockID) (*Duties, error) {
+	ctx, span := tracer.Start(ctx, "syncduties.GetSyncDuties", trace.WithAttributes(attribute.String("block_id", string(id))))
+	duties, err := s.getSyncDuties(ctx, id)
+	tracing.End(span, err)
+
+	return duties, err
+}
 
-	if slot > currentSlot+1 {
+// getSyncDuties resolves the sync committee of GetSyncDuties, within its span.
+func (s *Service) getSyncDuties(ctx context.Context, id beacon.BlockID) (*Duties, error) {
+	if requested, ok := id.Slot(); ok && s.Clock.IsFuture(requested) {
 		return nil, beacon.ErrSlotInFuture
 	}
 
-	validatorIndexes, err := s.BeaconService.FetchSyncCommitteeIndexes(ctx, slot)
+	// Finalized duties never change, so they are served without going upstream.
+	if duties := s.stored().LoadFinalized(id); duties != nil {
+		return duties, nil
+	}
+
+	stepCtx, span := tracer.Start(ctx, "resolve block")
+	block, err := s.resolveBlock(stepCtx, id)
+	tracing.End(span, err)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if duties := s.stored().LoadCanonical(block); duties != nil {
+		return duties, nil
+	}
+
+	// Both lookups use the resolved slot so they see the same state even if the head moves.
+	state := beacon.SlotStateID(block.Slot)
+
+	stepCtx, span = tracer.Start(ctx, "fetch sync committee")
+	validatorIndexes, err := s.BeaconService.FetchSyncCommitteeIndexes(stepCtx, state)
+	tracing.End(span, err)
+
 	if err != nil {
 		return nil, pkgerrors.Wrap(err, "fetch sync committee")
 	}
 
-	validators, err := s.BeaconService.FetchValidatorsByIDs(ctx, slot, validatorIndexes)
+	// The validators are fetched in concurrent chunks, each traced as its own upstream request.
+	stepCtx, span = tracer.Start(ctx, "fetch validators", trace.WithAttributes(attribute.Int("validators", len(validatorIndexes))))
+	validators, err := s.BeaconService.FetchValidatorsByIDs(stepCtx, state, validatorIndexes)
+	tracing.End(span, err)
+
 	if err != nil {
 		return nil, pkgerrors.Wrap(err, "fetch validators by ids")
 	}
 
-	return validators, nil
+	members := make([]Member, len(validators))
+	for i, v := range validators {
+		members[i] = Member{
+			Position:       i,
+			ValidatorIndex: v.Index,
+			Pubkey:         v.Validator.Pubkey,
+		}
+	}
+
+	duties := &Duties{
+		Slot:      block.Slot,
+		BlockRoot: block.Root,
+		Members:   members,
+		Finalized: block.Finalized,
+	}
+
+	s.stored().Save(block, duties)
+
+	return duties, nil
+}
+
+// resolveBlock resolves a block identifier to its slot, block root and finality. Sync committees
+// exist for missed slots too, so a slot without a block resolves with an empty root.
+// Returns ErrBlockNotCanonical for a block that was reorged out.
+func (s *Service) resolveBlock(ctx context.Context, id beacon.BlockID) (store.Meta, error) {
+	header, err := s.BeaconService.GetBeaconHeader(ctx, id)
+	if err != nil {
+		if requested, ok := id.Slot(); ok && errors.Is(pkgerrors.Cause(err), beacon.ErrSlotMissedOrDoesNotExist) {
+			return store.Meta{Slot: requested}, nil
+		}
+
+		return store.Meta{}, pkgerrors.Wrap(err, "fetch block header")
+	}
+
+	// The committee is read from the canonical state at the slot, which a reorged out block is not part of.
+	if !header.Data.Canonical {
+		return store.Meta{}, pkgerrors.Wrapf(beacon.ErrBlockNotCanonical,
+			"block %s at slot %d", header.Data.Root, header.Data.Header.Message.Slot)
+	}
+
+	return store.Meta{
+		Slot:      header.Data.Header.Message.Slot,
+		Root:      header.Data.Root,
+		Finalized: header.Finalized,
+	}, nil
 }
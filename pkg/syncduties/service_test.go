@@ -0,0 +1,217 @@
+package syncduties_test
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/store"
+	"github.com/powerslider/ethereum-validator-api/pkg/syncduties"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockBeaconService struct {
+	// roots holds the canonical block root of each slot; other slots were missed.
+	roots     map[uint64]string
+	finalized bool
+
+	headerCalls    int
+	committeeCalls int
+}
+
+func (m *mockBeaconService) GetBeaconHeader(ctx context.Context, id beacon.BlockID) (*beacon.BlockHeaderResponse, error) {
+	m.headerCalls++
+
+	slot, _ := id.Slot()
+
+	root, ok := m.roots[slot]
+	if !ok {
+		return nil, pkgerrors.Wrapf(beacon.ErrSlotMissedOrDoesNotExist, "slot %d", slot)
+	}
+
+	return &beacon.BlockHeaderResponse{
+		Data: beacon.BlockHeaderData{
+			Root:      root,
+			Canonical: true,
+			Header:    beacon.Header{Message: beacon.HeaderMessage{Slot: slot}},
+		},
+		Finalized: m.finalized,
+	}, nil
+}
+
+func (m *mockBeaconService) FetchSyncCommitteeIndexes(ctx context.Context, state beacon.StateID) ([]string, error) {
+	m.committeeCalls++
+
+	return []string{"7", "3", "7"}, nil
+}
+
+func (m *mockBeaconService) FetchValidatorsByIDs(ctx context.Context, state beacon.StateID, ids []string) ([]beacon.ValidatorEntry, error) {
+	entries := make([]beacon.ValidatorEntry, len(ids))
+	for i, id := range ids {
+		entries[i] = beacon.ValidatorEntry{Index: id, Validator: beacon.ValidatorInfo{Pubkey: "0xpubkey" + id}}
+	}
+
+	return entries, nil
+}
+
+type mockClock struct{}
+
+func (mockClock) IsFuture(slot uint64) bool {
+	return slot > 1000
+}
+
+func newService(t *testing.T, beaconSvc *mockBeaconService) *syncduties.Service {
+	t.Helper()
+
+	st, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = st.Close() })
+
+	svc := syncduties.NewService(beaconSvc, mockClock{})
+	svc.Store = st
+
+	return svc
+}
+
+func TestGetSyncDuties(t *testing.T) {
+	t.Parallel()
+
+	t.Run("committee in order with repeated members", func(t *testing.T) {
+		t.Parallel()
+
+		svc := syncduties.NewService(&mockBeaconService{roots: map[uint64]string{100: "0xa"}}, mockClock{})
+
+		duties, err := svc.GetSyncDuties(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+		assert.Equal(t, &syncduties.Duties{
+			Slot:      100,
+			BlockRoot: "0xa",
+			Members: []syncduties.Member{
+				{Position: 0, ValidatorIndex: "7", Pubkey: "0xpubkey7"},
+				{Position: 1, ValidatorIndex: "3", Pubkey: "0xpubkey3"},
+				{Position: 2, ValidatorIndex: "7", Pubkey: "0xpubkey7"},
+			},
+		}, duties)
+	})
+
+	t.Run("missed slots have a committee", func(t *testing.T) {
+		t.Parallel()
+
+		svc := syncduties.NewService(&mockBeaconService{}, mockClock{})
+
+		duties, err := svc.GetSyncDuties(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+		assert.Empty(t, duties.BlockRoot)
+		assert.Len(t, duties.Members, 3)
+	})
+
+	t.Run("future slot", func(t *testing.T) {
+		t.Parallel()
+
+		svc := syncduties.NewService(&mockBeaconService{}, mockClock{})
+
+		_, err := svc.GetSyncDuties(context.Background(), beacon.SlotBlockID(1001))
+		require.ErrorIs(t, err, beacon.ErrSlotInFuture)
+	})
+}
+
+func TestGetSyncDutiesStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("finalized duties are read from the store before going upstream", func(t *testing.T) {
+		t.Parallel()
+
+		beaconSvc := &mockBeaconService{roots: map[uint64]string{100: "0xa"}, finalized: true}
+		svc := newService(t, beaconSvc)
+
+		computed, err := svc.GetSyncDuties(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+		assert.True(t, computed.Finalized)
+
+		for _, id := range []beacon.BlockID{beacon.SlotBlockID(100), "0xa"} {
+			duties, err := svc.GetSyncDuties(context.Background(), id)
+			require.NoError(t, err)
+			assert.Equal(t, computed, duties, id)
+		}
+
+		assert.Equal(t, 1, beaconSvc.headerCalls)
+		assert.Equal(t, 1, beaconSvc.committeeCalls)
+	})
+
+	t.Run("non-finalized duties are rechecked against the canonical root", func(t *testing.T) {
+		t.Parallel()
+
+		beaconSvc := &mockBeaconService{roots: map[uint64]string{100: "0xa"}}
+		svc := newService(t, beaconSvc)
+
+		_, err := svc.GetSyncDuties(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+
+		// Still canonical: the block is resolved again, the committee is not.
+		duties, err := svc.GetSyncDuties(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+		assert.Equal(t, "0xa", duties.BlockRoot)
+		assert.Equal(t, 2, beaconSvc.headerCalls)
+		assert.Equal(t, 1, beaconSvc.committeeCalls)
+
+		// A reorg replaced the block: the stored duties are discarded and computed again.
+		beaconSvc.roots[100] = "0xb"
+
+		duties, err = svc.GetSyncDuties(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+		assert.Equal(t, "0xb", duties.BlockRoot)
+		assert.Equal(t, 2, beaconSvc.committeeCalls)
+	})
+
+	t.Run("stored duties are finalized with their block", func(t *testing.T) {
+		t.Parallel()
+
+		beaconSvc := &mockBeaconService{roots: map[uint64]string{100: "0xa"}}
+		svc := newService(t, beaconSvc)
+
+		_, err := svc.GetSyncDuties(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+
+		beaconSvc.finalized = true
+
+		duties, err := svc.GetSyncDuties(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+		assert.True(t, duties.Finalized)
+		assert.Equal(t, 1, beaconSvc.committeeCalls)
+
+		// Now served from the store alone.
+		calls := beaconSvc.headerCalls
+
+		_, err = svc.GetSyncDuties(context.Background(), beacon.SlotBlockID(100))
+		require.NoError(t, err)
+		assert.Equal(t, calls, beaconSvc.headerCalls)
+	})
+
+	t.Run("reorgs discard non-finalized duties", func(t *testing.T) {
+		t.Parallel()
+
+		beaconSvc := &mockBeaconService{roots: map[uint64]string{}}
+		svc := newService(t, beaconSvc)
+
+		for slot := uint64(100); slot < 103; slot++ {
+			beaconSvc.roots[slot] = "0x" + strconv.FormatUint(slot, 10)
+
+			_, err := svc.GetSyncDuties(context.Background(), beacon.SlotBlockID(slot))
+			require.NoError(t, err)
+		}
+
+		svc.InvalidateFrom(101)
+
+		for slot := uint64(100); slot < 103; slot++ {
+			_, err := svc.GetSyncDuties(context.Background(), beacon.SlotBlockID(slot))
+			require.NoError(t, err)
+		}
+
+		// Only the committees of the invalidated slots were fetched again.
+		assert.Equal(t, 5, beaconSvc.committeeCalls)
+	})
+}
@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	pkgerrors "github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName identifies this service in exported traces.
+const ServiceName = "ethereum-validator-api"
+
+// Setup installs the W3C trace context propagator and, when endpoint (an OTLP/HTTP URL such as
+// http://localhost:4318) is set, a tracer provider exporting spans to it. Without an endpoint
+// spans are not recorded. The returned function flushes pending spans and stops the exporter.
+func Setup(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "create otlp exporter")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of an instrumented package.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// End records err, if any, on span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// Middleware starts a span for every request to the routes of a mux.Router, continuing the trace
+// of the caller when the request carries W3C trace context. Spans are named by route template.
+func Middleware(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "http.server", otelhttp.WithSpanNameFormatter(
+		func(_ string, r *http.Request) string {
+			if route := mux.CurrentRoute(r); route != nil {
+				if template, err := route.GetPathTemplate(); err == nil {
+					return r.Method + " " + template
+				}
+			}
+
+			return r.Method
+		},
+	))
+}
+
+// Transport starts a span for every outbound request and propagates the trace context to the upstream.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(next)
+}
@@ -0,0 +1,61 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/powerslider/ethereum-validator-api/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestPropagation follows a request carrying W3C trace context through the router
+// to an upstream call. It installs a global tracer provider, so it does not run in parallel.
+func TestPropagation(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	_, err := tracing.Setup(context.Background(), "")
+	require.NoError(t, err)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	var upstreamTraceparent string
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamTraceparent = r.Header.Get("Traceparent")
+	}))
+	t.Cleanup(upstream.Close)
+
+	client := &http.Client{Transport: tracing.Transport(http.DefaultTransport)}
+
+	r := mux.NewRouter()
+	r.Use(tracing.Middleware)
+	r.HandleFunc("/things/{id}", func(w http.ResponseWriter, r *http.Request) {
+		req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, upstream.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/things/1", nil)
+	req.Header.Set("Traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	server := spans[1]
+	assert.Equal(t, "GET /things/{id}", server.Name())
+	assert.Equal(t, traceID, server.SpanContext().TraceID().String())
+	// The upstream continues the trace under the client span.
+	assert.Contains(t, upstreamTraceparent, traceID+"-"+spans[0].SpanContext().SpanID().String())
+}
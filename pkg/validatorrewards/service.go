@@ -0,0 +1,426 @@
+package validatorrewards
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/blockreward"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultMaxEpochs is the default maximum number of epochs per request (about 3.4 hours).
+	DefaultMaxEpochs = 32
+	// DefaultConcurrency is the default number of epochs fetched in parallel.
+	DefaultConcurrency = 4
+	// DefaultSlotConcurrency is the default number of slots of an epoch fetched in parallel.
+	DefaultSlotConcurrency = 8
+)
+
+var (
+	ErrInvalidRange     = errors.New("invalid epoch range")
+	ErrRangeTooLarge    = errors.New("epoch range exceeds the maximum allowed")
+	ErrEpochNotFinished = errors.New("epoch rewards are not known yet")
+)
+
+type BeaconService interface {
+	SyncCommitteeService
+	GetValidator(ctx context.Context, state beacon.StateID, id beacon.ValidatorID) (*beacon.ValidatorResponse, error)
+	GetProposerDuties(ctx context.Context, epoch uint64) (*beacon.ProposerDutiesResponse, error)
+	GetAttestationRewards(ctx context.Context, epoch uint64, ids []string) (*beacon.AttestationRewardsResponse, error)
+	GetBlockRewardFromConsensus(ctx context.Context, blockRoot string) (*beacon.RewardResponse, error)
+}
+
+// SyncCommitteeService serves the sync committee lookups, made for every slot of an epoch.
+// It is satisfied by *beacon.Service and by the caching *cache.BeaconService.
+type SyncCommitteeService interface {
+	FetchSyncCommitteeIndexes(ctx context.Context, state beacon.StateID) ([]string, error)
+	GetSyncCommitteeRewards(ctx context.Context, id beacon.BlockID, ids []string) (*beacon.SyncCommitteeRewardsResponse, error)
+}
+
+// BlockRewardService computes the reward of a proposed block. It is satisfied by *blockreward.Service.
+type BlockRewardService interface {
+	GetBlockReward(ctx context.Context, id beacon.BlockID) (*blockreward.Result, error)
+}
+
+// Clock converts between slots and epochs. It is satisfied by *chaintime.Clock.
+type Clock interface {
+	CurrentSlot() uint64
+	EpochOf(slot uint64) uint64
+	EpochStartSlot(epoch uint64) uint64
+}
+
+// Breakdown is a validator's rewards by source, in Gwei. Penalties are negative.
+type Breakdown struct {
+	AttestationHead           int64
+	AttestationTarget         int64
+	AttestationSource         int64
+	AttestationInclusionDelay int64
+	AttestationInactivity     int64
+	SyncCommittee             int64
+	// ProposalConsensus is the consensus-layer reward of proposed blocks.
+	ProposalConsensus int64
+	// ProposalExecution is the execution-layer reward of proposed blocks (fractional Gwei truncated).
+	ProposalExecution int64
+	Total             int64
+}
+
+// add adds the rewards of other to b.
+func (b *Breakdown) add(other Breakdown) {
+	b.AttestationHead += other.AttestationHead
+	b.AttestationTarget += other.AttestationTarget
+	b.AttestationSource += other.AttestationSource
+	b.AttestationInclusionDelay += other.AttestationInclusionDelay
+	b.AttestationInactivity += other.AttestationInactivity
+	b.SyncCommittee += other.SyncCommittee
+	b.ProposalConsensus += other.ProposalConsensus
+	b.ProposalExecution += other.ProposalExecution
+	b.Total += other.Total
+}
+
+// Proposal is a block the validator was scheduled to propose.
+type Proposal struct {
+	// BlockRoot is empty for missed slots and pre-Merge blocks.
+	BlockRoot     string
+	Slot          uint64
+	ConsensusGwei int64
+	ExecutionGwei int64
+	Missed        bool
+}
+
+// EpochRewards holds the rewards of a single epoch.
+type EpochRewards struct {
+	Proposals []Proposal
+	Breakdown
+	Epoch uint64
+	// SyncCommittee reports whether the validator was a sync committee member during the epoch.
+	SyncCommittee bool
+}
+
+// Result holds the per-epoch rewards of a validator, ordered by epoch, and their totals.
+type Result struct {
+	ValidatorIndex string
+	Epochs         []EpochRewards
+	Totals         Breakdown
+	FromEpoch      uint64
+	ToEpoch        uint64
+}
+
+// Service computes the rewards a validator earned over a range of epochs.
+type Service struct {
+	BeaconService BeaconService
+	// SyncCommittee serves the sync committee lookups, BeaconService by default. Point it at a
+	// cache to reuse the per-slot rewards across requests.
+	SyncCommittee SyncCommitteeService
+	BlockRewards  BlockRewardService
+	Clock         Clock
+	// MaxEpochs is the maximum number of epochs per request (DefaultMaxEpochs when zero).
+	MaxEpochs uint64
+	// Concurrency bounds the epochs fetched in parallel (DefaultConcurrency when zero).
+	Concurrency int
+	// SlotConcurrency bounds the slots of an epoch fetched in parallel for sync committee rewards
+	// (DefaultSlotConcurrency when zero).
+	SlotConcurrency int
+}
+
+// NewService creates a new validator reward service instance.
+func NewService(svc BeaconService, rewards BlockRewardService, clock Clock) *Service {
+	return &Service{
+		BeaconService:   svc,
+		SyncCommittee:   svc,
+		BlockRewards:    rewards,
+		Clock:           clock,
+		MaxEpochs:       DefaultMaxEpochs,
+		Concurrency:     DefaultConcurrency,
+		SlotConcurrency: DefaultSlotConcurrency,
+	}
+}
+
+// GetValidatorRewards combines the attestation, sync committee and block proposal rewards of a
+// validator (index or public key) for every epoch in [from, to]. Returns ErrInvalidRange,
+// ErrRangeTooLarge, ErrEpochNotFinished or beacon.ErrValidatorNotFound when applicable.
+func (s *Service) GetValidatorRewards(ctx context.Context, id beacon.ValidatorID, from, to uint64) (*Result, error) {
+	if err := s.validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	validator, err := s.BeaconService.GetValidator(ctx, beacon.StateID(beacon.BlockHead), id)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch validator")
+	}
+
+	index := validator.Data.Index
+	epochs := make([]EpochRewards, to-from+1)
+
+	concurrency := s.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+
+	for i := range epochs {
+		epoch := from + uint64(i)
+
+		g.Go(func() error {
+			rewards, err := s.epochRewards(ctx, index, epoch)
+			if err != nil {
+				return pkgerrors.Wrapf(err, "epoch %d", epoch)
+			}
+
+			// Each goroutine writes to its own index, which keeps epochs ordered without locking.
+			epochs[i] = *rewards
+
+			return nil
+		})
+	}
+
+	if err = g.Wait(); err != nil {
+		return nil, pkgerrors.Wrap(err, "fetch validator rewards")
+	}
+
+	result := &Result{
+		ValidatorIndex: index,
+		FromEpoch:      from,
+		ToEpoch:        to,
+		Epochs:         epochs,
+	}
+
+	for _, epoch := range epochs {
+		result.Totals.add(epoch.Breakdown)
+	}
+
+	return result, nil
+}
+
+// validateRange checks the epoch range against the configured maximum and the current epoch.
+func (s *Service) validateRange(from, to uint64) error {
+	if from > to {
+		return pkgerrors.Wrapf(ErrInvalidRange, "from epoch %d is after to epoch %d", from, to)
+	}
+
+	maxEpochs := s.MaxEpochs
+	if maxEpochs == 0 {
+		maxEpochs = DefaultMaxEpochs
+	}
+
+	// Compared without adding one, which overflows for the whole uint64 range.
+	if to-from >= maxEpochs {
+		return pkgerrors.Wrapf(ErrRangeTooLarge, "epochs %d to %d requested, at most %d allowed", from, to, maxEpochs)
+	}
+
+	// Attestations of an epoch can be included until the end of the next one, so its
+	// rewards are only settled once that epoch is over.
+	current := s.Clock.EpochOf(s.Clock.CurrentSlot())
+	if current < 2 || to > current-2 {
+		return pkgerrors.Wrapf(ErrEpochNotFinished, "epoch %d, latest settled epoch is %d", to, max(current, 2)-2)
+	}
+
+	return nil
+}
+
+// epochRewards computes the rewards of the validator at index for a single epoch.
+func (s *Service) epochRewards(ctx context.Context, index string, epoch uint64) (*EpochRewards, error) {
+	rewards := &EpochRewards{Epoch: epoch}
+
+	if err := s.addAttestationRewards(ctx, rewards, index); err != nil {
+		return nil, err
+	}
+
+	if err := s.addSyncCommitteeRewards(ctx, rewards, index); err != nil {
+		return nil, err
+	}
+
+	if err := s.addProposalRewards(ctx, rewards, index); err != nil {
+		return nil, err
+	}
+
+	return rewards, nil
+}
+
+// addAttestationRewards adds the attestation rewards of the epoch. Inactive validators have none.
+func (s *Service) addAttestationRewards(ctx context.Context, rewards *EpochRewards, index string) error {
+	resp, err := s.BeaconService.GetAttestationRewards(ctx, rewards.Epoch, []string{index})
+	if err != nil {
+		return pkgerrors.Wrap(err, "fetch attestation rewards")
+	}
+
+	for _, r := range resp.Data.TotalRewards {
+		if r.ValidatorIndex != index {
+			continue
+		}
+
+		rewards.AttestationHead += r.Head
+		rewards.AttestationTarget += r.Target
+		rewards.AttestationSource += r.Source
+		rewards.AttestationInclusionDelay += r.InclusionDelay
+		rewards.AttestationInactivity += r.Inactivity
+		rewards.Total += r.Head + r.Target + r.Source + r.InclusionDelay + r.Inactivity
+	}
+
+	return nil
+}
+
+// addSyncCommitteeRewards adds the sync committee rewards of every block of the epoch
+// when the validator is a member of the sync committee at the time. The blocks are fetched
+// SlotConcurrency at a time.
+func (s *Service) addSyncCommitteeRewards(ctx context.Context, rewards *EpochRewards, index string) error {
+	start := s.Clock.EpochStartSlot(rewards.Epoch)
+	end := s.Clock.EpochStartSlot(rewards.Epoch + 1)
+
+	committee, err := s.SyncCommittee.FetchSyncCommitteeIndexes(ctx, beacon.SlotStateID(start))
+
+	switch cause := pkgerrors.Cause(err); {
+	case err == nil:
+	case errors.Is(cause, beacon.ErrSlotWasMissed), errors.Is(cause, beacon.ErrDutiesNotFound):
+		// Pre-Altair epoch, there is no sync committee.
+		return nil
+	default:
+		return pkgerrors.Wrap(err, "fetch sync committee")
+	}
+
+	if !slices.Contains(committee, index) {
+		return nil
+	}
+
+	rewards.SyncCommittee = true
+
+	concurrency := s.SlotConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultSlotConcurrency
+	}
+
+	// Each goroutine writes the reward of its own slot, which are summed once all are in.
+	slotRewards := make([]int64, end-start)
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+
+	for i := range slotRewards {
+		slot := start + uint64(i)
+
+		g.Go(func() error {
+			resp, err := s.SyncCommittee.GetSyncCommitteeRewards(ctx, beacon.SlotBlockID(slot), []string{index})
+
+			switch cause := pkgerrors.Cause(err); {
+			case err == nil:
+			case errors.Is(cause, beacon.ErrSlotMissedOrDoesNotExist):
+				return nil
+			default:
+				return pkgerrors.Wrapf(err, "fetch sync committee rewards of slot %d", slot)
+			}
+
+			for _, r := range resp.Data {
+				if r.ValidatorIndex == index {
+					slotRewards[i] += r.Reward
+				}
+			}
+
+			return nil
+		})
+	}
+
+	if err = g.Wait(); err != nil {
+		return err //nolint:wrapcheck // wrapped with the slot above
+	}
+
+	for _, reward := range slotRewards {
+		rewards.Breakdown.SyncCommittee += reward
+		rewards.Total += reward
+	}
+
+	return nil
+}
+
+// addProposalRewards adds the rewards of the blocks the validator proposed in the epoch.
+func (s *Service) addProposalRewards(ctx context.Context, rewards *EpochRewards, index string) error {
+	duties, err := s.BeaconService.GetProposerDuties(ctx, rewards.Epoch)
+	if err != nil {
+		return pkgerrors.Wrap(err, "fetch proposer duties")
+	}
+
+	for _, duty := range duties.Data {
+		if duty.ValidatorIndex != index {
+			continue
+		}
+
+		proposal, err := s.proposal(ctx, duty.Slot)
+		if err != nil {
+			return err
+		}
+
+		rewards.Proposals = append(rewards.Proposals, *proposal)
+		rewards.ProposalConsensus += proposal.ConsensusGwei
+		rewards.ProposalExecution += proposal.ExecutionGwei
+		rewards.Total += proposal.ConsensusGwei + proposal.ExecutionGwei
+	}
+
+	return nil
+}
+
+// proposal computes the reward of the block proposed at slot.
+func (s *Service) proposal(ctx context.Context, slot uint64) (*Proposal, error) {
+	proposal := &Proposal{Slot: slot}
+
+	result, err := s.BlockRewards.GetBlockReward(ctx, beacon.SlotBlockID(slot))
+
+	switch cause := pkgerrors.Cause(err); {
+	case err == nil:
+	case errors.Is(cause, beacon.ErrSlotMissedOrDoesNotExist):
+		proposal.Missed = true
+		return proposal, nil
+	case errors.Is(cause, beacon.ErrNoExecutionPayload):
+		// A pre-Merge block still earned its consensus reward, there is just no execution part.
+		return s.consensusOnlyProposal(ctx, proposal)
+	default:
+		return nil, pkgerrors.Wrapf(err, "fetch block reward of slot %d", slot)
+	}
+
+	consensus, err := parseGwei(result.Reward, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal.BlockRoot = result.BlockRoot
+	proposal.ConsensusGwei = consensus
+
+	// TotalGwei is the consensus and execution reward together.
+	if result.TotalGwei != nil {
+		proposal.ExecutionGwei = result.TotalGwei.Int64() - consensus
+	}
+
+	return proposal, nil
+}
+
+// consensusOnlyProposal completes the proposal of a block without execution payload with the
+// consensus reward of the block.
+func (s *Service) consensusOnlyProposal(ctx context.Context, proposal *Proposal) (*Proposal, error) {
+	resp, err := s.BeaconService.GetBlockRewardFromConsensus(ctx, strconv.FormatUint(proposal.Slot, 10))
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "fetch consensus reward of slot %d", proposal.Slot)
+	}
+
+	consensus, err := parseGwei(resp.Data.Total, proposal.Slot)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal.ConsensusGwei = consensus
+
+	return proposal, nil
+}
+
+// parseGwei parses the consensus reward of the block at slot.
+func parseGwei(reward string, slot uint64) (int64, error) {
+	consensus, err := strconv.ParseInt(reward, 10, 64)
+	if err != nil {
+		return 0, pkgerrors.Wrapf(err, "parse block reward of slot %d", slot)
+	}
+
+	return consensus, nil
+}
@@ -0,0 +1,200 @@
+package validatorrewards_test
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/powerslider/ethereum-validator-api/pkg/beacon"
+	"github.com/powerslider/ethereum-validator-api/pkg/blockreward"
+	"github.com/powerslider/ethereum-validator-api/pkg/chaintime"
+	"github.com/powerslider/ethereum-validator-api/pkg/validatorrewards"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock uses the mainnet genesis time and spec.
+var testClock = chaintime.NewClock(time.Unix(1606824023, 0), 12, 32, 256)
+
+// mockBeaconService serves validator 42, which proposes the first and third slot of every epoch
+// and is a sync committee member from epoch 11 on. Slot 353 was missed.
+type mockBeaconService struct{}
+
+func (mockBeaconService) GetValidator(
+	ctx context.Context,
+	state beacon.StateID,
+	id beacon.ValidatorID,
+) (*beacon.ValidatorResponse, error) {
+	if id != "42" {
+		return nil, pkgerrors.Wrapf(beacon.ErrValidatorNotFound, "validator %s", id)
+	}
+
+	return &beacon.ValidatorResponse{Data: beacon.ValidatorEntry{Index: "42"}}, nil
+}
+
+func (mockBeaconService) GetProposerDuties(ctx context.Context, epoch uint64) (*beacon.ProposerDutiesResponse, error) {
+	start := epoch * 32
+
+	return &beacon.ProposerDutiesResponse{Data: []beacon.ProposerDuty{
+		{ValidatorIndex: "42", Slot: start},
+		{ValidatorIndex: "7", Slot: start + 1},
+		{ValidatorIndex: "42", Slot: start + 2},
+	}}, nil
+}
+
+func (mockBeaconService) GetAttestationRewards(
+	ctx context.Context,
+	epoch uint64,
+	ids []string,
+) (*beacon.AttestationRewardsResponse, error) {
+	resp := &beacon.AttestationRewardsResponse{}
+	resp.Data.TotalRewards = []beacon.AttestationReward{
+		{ValidatorIndex: "42", Head: 10, Target: 20, Source: 15, Inactivity: -1},
+	}
+
+	return resp, nil
+}
+
+func (mockBeaconService) FetchSyncCommitteeIndexes(ctx context.Context, state beacon.StateID) ([]string, error) {
+	if state == "320" {
+		return nil, pkgerrors.Wrap(beacon.ErrSlotWasMissed, "not activated for Altair")
+	}
+
+	return []string{"1", "42"}, nil
+}
+
+func (mockBeaconService) GetSyncCommitteeRewards(
+	ctx context.Context,
+	id beacon.BlockID,
+	ids []string,
+) (*beacon.SyncCommitteeRewardsResponse, error) {
+	if id == "353" {
+		return nil, beacon.ErrSlotMissedOrDoesNotExist
+	}
+
+	return &beacon.SyncCommitteeRewardsResponse{Data: []beacon.SyncCommitteeReward{
+		{ValidatorIndex: "42", Reward: 5},
+	}}, nil
+}
+
+func (mockBeaconService) GetBlockRewardFromConsensus(ctx context.Context, blockRoot string) (*beacon.RewardResponse, error) {
+	return &beacon.RewardResponse{Data: beacon.RewardData{Total: "25000000"}}, nil
+}
+
+// countingSyncCommittee counts the sync committee reward lookups, which run concurrently.
+type countingSyncCommittee struct {
+	mockBeaconService
+	rewardCalls atomic.Int32
+}
+
+func (c *countingSyncCommittee) GetSyncCommitteeRewards(
+	ctx context.Context,
+	id beacon.BlockID,
+	ids []string,
+) (*beacon.SyncCommitteeRewardsResponse, error) {
+	c.rewardCalls.Add(1)
+
+	return c.mockBeaconService.GetSyncCommitteeRewards(ctx, id, ids)
+}
+
+// mockBlockRewardService reports slot 354 as missed and slot 322 as a pre-Merge block.
+type mockBlockRewardService struct{}
+
+func (mockBlockRewardService) GetBlockReward(ctx context.Context, id beacon.BlockID) (*blockreward.Result, error) {
+	switch id {
+	case "354":
+		return nil, pkgerrors.Wrap(beacon.ErrSlotMissedOrDoesNotExist, "fetch block header")
+	case "322":
+		return nil, pkgerrors.Wrap(beacon.ErrNoExecutionPayload, "fetch execution payload")
+	}
+
+	return &blockreward.Result{
+		BlockRoot: "0xroot",
+		Reward:    "30000000",
+		TotalGwei: big.NewInt(30_500_000),
+	}, nil
+}
+
+func TestGetValidatorRewards(t *testing.T) {
+	t.Parallel()
+
+	svc := validatorrewards.NewService(mockBeaconService{}, mockBlockRewardService{}, testClock)
+
+	t.Run("combines rewards per epoch", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := svc.GetValidatorRewards(context.Background(), "42", 10, 11)
+		require.NoError(t, err)
+		require.Len(t, result.Epochs, 2)
+
+		first := result.Epochs[0]
+		assert.Equal(t, uint64(10), first.Epoch)
+		assert.False(t, first.SyncCommittee)
+		require.Len(t, first.Proposals, 2)
+		// The pre-Merge block earned its consensus reward only.
+		assert.Equal(t, validatorrewards.Proposal{Slot: 322, ConsensusGwei: 25_000_000}, first.Proposals[1])
+		assert.Equal(t, int64(55_000_000), first.ProposalConsensus)
+		assert.Equal(t, int64(500_000), first.ProposalExecution)
+		assert.Equal(t, int64(55_500_044), first.Total)
+
+		second := result.Epochs[1]
+		assert.True(t, second.SyncCommittee)
+		// 31 of the 32 slots were proposed.
+		assert.Equal(t, int64(155), second.Breakdown.SyncCommittee)
+		require.Len(t, second.Proposals, 2)
+		assert.True(t, second.Proposals[1].Missed)
+		assert.Equal(t, int64(30_500_199), second.Total)
+
+		assert.Equal(t, "42", result.ValidatorIndex)
+		assert.Equal(t, int64(20), result.Totals.AttestationHead)
+		assert.Equal(t, int64(-2), result.Totals.AttestationInactivity)
+		assert.Equal(t, int64(86_000_243), result.Totals.Total)
+	})
+
+	t.Run("sync committee lookups go through SyncCommittee", func(t *testing.T) {
+		t.Parallel()
+
+		syncCommittee := &countingSyncCommittee{}
+
+		svc := validatorrewards.NewService(mockBeaconService{}, mockBlockRewardService{}, testClock)
+		svc.SyncCommittee = syncCommittee
+		svc.SlotConcurrency = 3
+
+		result, err := svc.GetValidatorRewards(context.Background(), "42", 11, 11)
+		require.NoError(t, err)
+		assert.Equal(t, int64(155), result.Totals.SyncCommittee)
+		assert.Equal(t, int32(32), syncCommittee.rewardCalls.Load())
+	})
+
+	t.Run("invalid ranges", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := svc.GetValidatorRewards(context.Background(), "42", 11, 10)
+		require.ErrorIs(t, err, validatorrewards.ErrInvalidRange)
+
+		_, err = svc.GetValidatorRewards(context.Background(), "42", 10, 10+validatorrewards.DefaultMaxEpochs)
+		require.ErrorIs(t, err, validatorrewards.ErrRangeTooLarge)
+
+		_, err = svc.GetValidatorRewards(context.Background(), "42", 0, math.MaxUint64)
+		require.ErrorIs(t, err, validatorrewards.ErrRangeTooLarge)
+
+		current := testClock.EpochOf(testClock.CurrentSlot())
+		_, err = svc.GetValidatorRewards(context.Background(), "42", current-1, current-1)
+		require.ErrorIs(t, err, validatorrewards.ErrEpochNotFinished)
+
+		// Epochs at the end of the uint64 range must not wrap around into the past.
+		_, err = svc.GetValidatorRewards(context.Background(), "42", math.MaxUint64-1, math.MaxUint64)
+		require.ErrorIs(t, err, validatorrewards.ErrEpochNotFinished)
+	})
+
+	t.Run("validator not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := svc.GetValidatorRewards(context.Background(), "7", 10, 10)
+		require.ErrorIs(t, err, beacon.ErrValidatorNotFound)
+	})
+}